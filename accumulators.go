@@ -0,0 +1,78 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+)
+
+// checkIntegerAccumulator reports durations converted to integers and added to an integer variable, e.g.
+// `total += int64(d)`, whose value is later easily mistaken for another unit
+func (c *checker) checkIntegerAccumulator(stmt *ast.AssignStmt) {
+	acc, term, ok := accumulation(stmt)
+	if !ok {
+		return
+	}
+
+	t := c.pass.TypesInfo.TypeOf(acc)
+	if !isInteger(t) || c.classifier.IsDuration(t) || !c.isDurationToIntConversion(term) {
+		return
+	}
+
+	c.reportf(RuleIntAccumulator, stmt, "Duration accumulated into integer `%s` loses its unit: declare `%s` as time.Duration",
+		c.formatExpr(acc), c.formatExpr(acc))
+}
+
+// checkUnscaledConstant reports bare integer constants added to or subtracted from duration variables, e.g.
+// `total += 100` which adds 100 nanoseconds, when they are above the -unscaled-threshold
+func (c *checker) checkUnscaledConstant(stmt *ast.AssignStmt) {
+	acc, term, ok := accumulation(stmt)
+	if !ok {
+		return
+	}
+
+	if !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(acc)) || !isNumberLiteral(term) {
+		return
+	}
+
+	value, exact := constant.Int64Val(constant.ToInt(c.pass.TypesInfo.Types[term].Value))
+	if !exact || value <= int64(c.settings.unscaledThreshold) && value >= -int64(c.settings.unscaledThreshold) {
+		return
+	}
+
+	c.reportf(RuleUnscaledAdd, stmt, "Unscaled constant `%s` added to duration `%s` is %d nanoseconds: multiply it by a unit",
+		c.formatExpr(term), c.formatExpr(acc), value)
+}
+
+// isNumberLiteral reports whether the expression is a number literal, possibly negated
+func isNumberLiteral(expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && (unary.Op == token.SUB || unary.Op == token.ADD) {
+		expr = ast.Unparen(unary.X)
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && (lit.Kind == token.INT || lit.Kind == token.FLOAT)
+}
+
+// accumulation matches `acc += term`, `acc -= term`, `acc = acc + term` and `acc = acc - term` and returns acc and
+// term
+func accumulation(stmt *ast.AssignStmt) (ast.Expr, ast.Expr, bool) {
+	if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return nil, nil, false
+	}
+
+	acc := stmt.Lhs[0]
+	switch stmt.Tok {
+	case token.ADD_ASSIGN, token.SUB_ASSIGN:
+		return acc, stmt.Rhs[0], true
+	case token.ASSIGN:
+		sum, ok := ast.Unparen(stmt.Rhs[0]).(*ast.BinaryExpr)
+		if !ok || sum.Op != token.ADD && sum.Op != token.SUB || formatNode(ast.Unparen(sum.X)) != formatNode(acc) {
+			return nil, nil, false
+		}
+		return acc, sum.Y, true
+	default:
+		return nil, nil, false
+	}
+}
@@ -0,0 +1,64 @@
+package durationcheck
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// ruleAnalyzers are the analyzers of the individual rules, sharing the settings of Analyzer
+var ruleAnalyzers = newRuleAnalyzers(defaultSettings)
+
+// Analyzers returns an analyzer per rule, named after the code of the rule, e.g. durationcheck_sleep_literal for
+// sleep-literal, for drivers such as multicheckers and golangci-lint to run exactly the rules they want. Each analyzer
+// runs its rule whether it's opt-in or not, and shares the settings of Analyzer but those selecting the rules. Analyzer
+// still runs the rules selected by its flags in a single pass.
+func Analyzers() []*analysis.Analyzer {
+	return append([]*analysis.Analyzer(nil), ruleAnalyzers...)
+}
+
+// NewAnalyzers is like NewAnalyzer but returns an analyzer per rule like Analyzers, sharing the settings of the
+// options.
+func NewAnalyzers(opts ...Option) []*analysis.Analyzer {
+	return newRuleAnalyzers(applyOptions(opts))
+}
+
+func newRuleAnalyzers(s *settings) []*analysis.Analyzer {
+	analyzers := make([]*analysis.Analyzer, 0, len(rules))
+	for _, r := range rules {
+		analyzers = append(analyzers, newRuleAnalyzer(s, r))
+	}
+
+	return analyzers
+}
+
+func newRuleAnalyzer(s *settings, r *rule) *analysis.Analyzer {
+	doc := "check for two durations multiplied together"
+	if r.usage != "" {
+		doc = "report " + strings.TrimPrefix(r.usage, "flag ")
+	}
+
+	a := &analysis.Analyzer{
+		Name: ruleAnalyzerName(r.code),
+		Doc:  doc,
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return check(pass, s, r, pass.ResultOf[inspect.Analyzer].(*inspector.Inspector),
+				pass.ResultOf[timeoutFactsAnalyzer].(timeoutParams), pass.ResultOf[resultFactsAnalyzer].(map[*types.Func]durationexpr.Kind),
+				pass.ResultOf[productFactsAnalyzer].(paramProducts))
+		},
+		Requires:   []*analysis.Analyzer{inspect.Analyzer, timeoutFactsAnalyzer, resultFactsAnalyzer, productFactsAnalyzer},
+		ResultType: resultType,
+	}
+	s.registerShared(&a.Flags)
+
+	return a
+}
+
+// ruleAnalyzerName returns the name of the analyzer of a rule, a valid identifier as analyzer names must be
+func ruleAnalyzerName(code string) string {
+	return "durationcheck_" + strings.ReplaceAll(code, "-", "_")
+}
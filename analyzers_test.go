@@ -0,0 +1,87 @@
+package durationcheck_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzers(t *testing.T) {
+	analyzers := durationcheck.Analyzers()
+	if err := analysis.Validate(analyzers); err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]bool{}
+	for _, a := range analyzers {
+		names[a.Name] = true
+	}
+
+	for _, name := range []string{"durationcheck_mul", "durationcheck_bitwise", "durationcheck_sleep_literal", "durationcheck_printf"} {
+		if !names[name] {
+			t.Errorf("no analyzer named %s in %d analyzers", name, len(analyzers))
+		}
+	}
+}
+
+// TestRuleAnalyzer checks that the analyzer of an opt-in rule runs it without its flag, and only it
+func TestRuleAnalyzer(t *testing.T) {
+	var bitwise *analysis.Analyzer
+	for _, a := range durationcheck.Analyzers() {
+		if a.Name == "durationcheck_bitwise" {
+			bitwise = a
+		}
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, bitwise, "suite")
+}
+
+func TestNewAnalyzers(t *testing.T) {
+	analyzers := durationcheck.NewAnalyzers(durationcheck.WithUnscaledThreshold(10))
+	if err := analysis.Validate(analyzers); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(analyzers), len(durationcheck.Analyzers()); got != want {
+		t.Errorf("got %d analyzers, want %d", got, want)
+	}
+
+	if f := analyzers[0].Flags.Lookup("unscaled-threshold"); f == nil || f.Value.String() != "10" {
+		t.Errorf("unscaled-threshold flag of %s = %v, want 10", analyzers[0].Name, f)
+	}
+
+	if f := analyzers[0].Flags.Lookup("bitwise"); f != nil {
+		t.Errorf("%s defines the flag selecting the bitwise rule", analyzers[0].Name)
+	}
+}
+
+// discard ignores the errors of analysistest, for the analyzers whose diagnostics are checked by the test
+type discard struct{}
+
+func (discard) Errorf(string, ...interface{}) {}
+
+// TestRuleAnalyzersDirectives checks that the analyzers of the rules run together report an invalid directive once,
+// from the analyzer of mul, and don't report the directives used by other rules as unused
+func TestRuleAnalyzersDirectives(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "unused.yml"))
+
+	testdata := analysistest.TestData()
+	for _, a := range durationcheck.Analyzers() {
+		if a.Name == "durationcheck_mul" {
+			analysistest.Run(t, testdata, a, "ruledirectives")
+			continue
+		}
+
+		for _, result := range analysistest.Run(discard{}, testdata, a, "ruledirectives") {
+			for _, diag := range result.Diagnostics {
+				if diag.Category == "directive" {
+					t.Errorf("%s: unexpected diagnostic %q", a.Name, diag.Message)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+)
+
+// The helpers below expose the semantics of the mul rule to the authors of related analyzers, e.g. sleep checkers or
+// context timeout linters. They apply the default settings: the configuration of Analyzer, such as -duration-types,
+// -accept-names or -dataflow, doesn't change their answers. The durationexpr package offers the same classification
+// with a configurable Classifier.
+
+// IsDurationType returns true if the type is time.Duration or a pointer to it, directly or through type aliases.
+func IsDurationType(t types.Type) bool {
+	return durationexpr.IsDuration(t)
+}
+
+// IsAcceptableDurationExpr returns true if the duration expression is a dimensionless count that can be multiplied
+// by a unit, e.g. `10` or `time.Duration(n)` where n is a plain number, and false if it already carries a unit of
+// time, e.g. `time.Second` or a time.Duration variable.
+func IsAcceptableDurationExpr(pass *analysis.Pass, expr ast.Expr) bool {
+	return ClassifyOperand(pass, expr) == durationexpr.Count
+}
+
+// ClassifyOperand returns the kind of a duration expression as an operand of a multiplication.
+func ClassifyOperand(pass *analysis.Pass, expr ast.Expr) durationexpr.Kind {
+	classifier := &durationexpr.Classifier{Info: pass.TypesInfo}
+	return classifier.Classify(expr)
+}
+
+// MultiplicationOperands returns the operands of a multiplication of durations, in source order, with their
+// classification. Chains such as `a * b * time.Second` are flattened. It returns nil if the expression isn't a
+// multiplication or if an operand isn't a duration.
+func MultiplicationOperands(pass *analysis.Pass, expr *ast.BinaryExpr) []Operand {
+	if expr.Op != token.MUL {
+		return nil
+	}
+
+	classifier := &durationexpr.Classifier{Info: pass.TypesInfo}
+
+	var operands []Operand
+	for _, op := range multiplicationOperands(expr) {
+		tv, ok := pass.TypesInfo.Types[op]
+		if !ok || !classifier.IsDuration(tv.Type) {
+			return nil
+		}
+
+		operands = append(operands, Operand{Expr: op, Type: tv.Type, Kind: classifier.Classify(op)})
+	}
+
+	return operands
+}
+
+// IsSuspiciousMultiplication returns true if more than one operand of the multiplication of durations carries a
+// unit, as reported by the mul rule. The truncation idiom `(d / time.Second) * time.Second` isn't suspicious.
+func IsSuspiciousMultiplication(pass *analysis.Pass, expr *ast.BinaryExpr) bool {
+	if _, _, ok := truncationIdiom(pass, expr); ok {
+		return false
+	}
+
+	units := 0
+	for _, op := range MultiplicationOperands(pass, expr) {
+		if op.Kind == durationexpr.Unit {
+			units++
+		}
+	}
+
+	return units >= 2
+}
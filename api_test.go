@@ -0,0 +1,61 @@
+package durationcheck_test
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// sleepAnalyzer is a related analyzer built on the helpers: it reports the calls to time.Sleep passing a count, and
+// the classification of the operands of multiplications of durations
+var sleepAnalyzer = &analysis.Analyzer{
+	Name: "sleep",
+	Doc:  "test of the helpers",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		for _, file := range pass.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CallExpr:
+					fn, ok := typeutil.Callee(pass.TypesInfo, n).(*types.Func)
+					if ok && fn.FullName() == "time.Sleep" && durationcheck.IsDurationType(pass.TypesInfo.TypeOf(n.Args[0])) &&
+						durationcheck.IsAcceptableDurationExpr(pass, n.Args[0]) {
+						pass.Reportf(n.Pos(), "count slept: %s", types.ExprString(n.Args[0]))
+					}
+				case *ast.BinaryExpr:
+					operands := durationcheck.MultiplicationOperands(pass, n)
+					if operands == nil {
+						return true
+					}
+
+					kinds := make([]string, len(operands))
+					for i, op := range operands {
+						kinds[i] = op.Kind.String()
+					}
+
+					label := "operands"
+					if durationcheck.IsSuspiciousMultiplication(pass, n) {
+						label = "suspicious"
+					}
+					pass.Reportf(n.Pos(), "%s: %s", label, strings.Join(kinds, ", "))
+
+					return false
+				}
+
+				return true
+			})
+		}
+
+		return nil, nil
+	},
+}
+
+func TestHelpers(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, sleepAnalyzer, "api")
+}
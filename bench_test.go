@@ -0,0 +1,80 @@
+package durationcheck_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// benchmarkFiles is the number of files of the synthetic corpus, one in four using durations
+const benchmarkFiles = 200
+
+func BenchmarkAnalyzer(b *testing.B) {
+	benchmarkAnalyzer(b, durationcheck.Analyzer)
+}
+
+func BenchmarkStandaloneAnalyzer(b *testing.B) {
+	benchmarkAnalyzer(b, durationcheck.StandaloneAnalyzer)
+}
+
+func benchmarkAnalyzer(b *testing.B, a *analysis.Analyzer) {
+	pkgs := loadCorpus(b)
+
+	for b.Loop() {
+		if _, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, &checker.Options{Sequential: true}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// loadCorpus writes and loads a package of benchmarkFiles files, most of them doing integer arithmetic only, like the
+// bulk of the files of a large codebase
+func loadCorpus(b *testing.B) []*packages.Package {
+	b.Helper()
+
+	dir := b.TempDir()
+	writeCorpusFile(b, filepath.Join(dir, "go.mod"), "module corpus\n\ngo 1.24\n")
+
+	for i := range benchmarkFiles {
+		var src strings.Builder
+		if i%4 == 0 {
+			fmt.Fprintf(&src, "package corpus\n\nimport \"time\"\n\nfunc durations%d(n int, d time.Duration) time.Duration {\n", i)
+			for j := range 50 {
+				fmt.Fprintf(&src, "\td += time.Duration(n+%d) * time.Millisecond\n", j)
+			}
+			src.WriteString("\treturn d * time.Second\n}\n")
+		} else {
+			fmt.Fprintf(&src, "package corpus\n\nfunc integers%d(n, m int) int {\n", i)
+			for j := range 50 {
+				fmt.Fprintf(&src, "\tn += (m + %d) * n / 3\n", j)
+			}
+			src.WriteString("\treturn n * m\n}\n")
+		}
+		writeCorpusFile(b, filepath.Join(dir, fmt.Sprintf("file%d.go", i)), src.String())
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, ".")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		b.Fatal("failed to load the corpus")
+	}
+
+	return pkgs
+}
+
+func writeCorpusFile(b *testing.B, filename, content string) {
+	b.Helper()
+
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		b.Fatal(err)
+	}
+}
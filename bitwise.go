@@ -0,0 +1,19 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// checkBitwiseOperation reports bitwise operations where at least one operand is a duration, e.g. `d & mask`
+func (c *checker) checkBitwiseOperation(expr *ast.BinaryExpr) {
+	switch expr.Op {
+	case token.AND, token.OR, token.XOR, token.AND_NOT:
+	default:
+		return
+	}
+
+	if c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(expr.X)) || c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(expr.Y)) {
+		c.reportf(RuleBitwise, expr, "Bitwise operation on durations: `%s`", c.formatExpr(expr))
+	}
+}
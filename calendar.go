@@ -0,0 +1,25 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// checkCalendarConversion reports time.Month and time.Weekday values converted to durations, e.g.
+// `d * time.Duration(month)`: calendar units don't have a fixed length, and their values are ordinals anyway
+func (c *checker) checkCalendarConversion(call *ast.CallExpr) {
+	if !c.classifier.IsConversion(call) {
+		return
+	}
+
+	arg := call.Args[0]
+	named, ok := types.Unalias(c.pass.TypesInfo.TypeOf(arg)).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "time" {
+		return
+	}
+
+	if name := named.Obj().Name(); name == "Month" || name == "Weekday" {
+		c.reportf(RuleCalendar, call, "Conversion of time.%s `%s` to duration: calendar units aren't fixed durations, use time.Time.AddDate",
+			name, c.formatExpr(arg))
+	}
+}
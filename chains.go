@@ -0,0 +1,134 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// minChainUnits is the number of unit constants from which a chain of multiplications and divisions is reported
+const minChainUnits = 3
+
+// checkUnitChain reports chains of multiplications and divisions scaling one value by three or more unit constants,
+// e.g. `time.Duration(n) * time.Second / time.Millisecond * time.Millisecond`, and suggests the equivalent obtained by
+// folding the constants
+func (c *checker) checkUnitChain(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	if !isScaling(expr) || isChainOperand(cur) || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(expr)) {
+		return
+	}
+
+	base, ops, operands := flattenChain(expr)
+	if c.isConstant(base) && len(operands) > 0 && ops[0] == token.MUL && !c.isConstant(operands[0]) {
+		base, operands[0] = operands[0], base
+	}
+
+	if c.isConstant(base) {
+		return
+	}
+
+	qualifier := ""
+	count := 0
+	factor := constant.MakeInt64(1)
+	for i, operand := range operands {
+		value := c.pass.TypesInfo.Types[operand].Value
+		if value == nil || value.Kind() != constant.Int {
+			return
+		}
+
+		if isUnitConstant(c.pass, operand) {
+			count++
+			qualifier, _ = unitQualifier(operand)
+		}
+
+		if ops[i] == token.MUL {
+			factor = constant.BinaryOp(factor, token.MUL, value)
+			continue
+		}
+
+		// dividing by a constant that doesn't divide the factor truncates, which folding would not preserve
+		if constant.Sign(value) == 0 || constant.Sign(constant.BinaryOp(factor, token.REM, value)) != 0 {
+			return
+		}
+		factor = constant.BinaryOp(factor, token.QUO_ASSIGN, value)
+	}
+
+	if count < minChainUnits {
+		return
+	}
+
+	c.reportf(RuleUnitChain, expr, "Convoluted unit conversion chain `%s`: simplify to `%s`", c.formatExpr(expr), truncate(scaled(base, factor, qualifier), c.settings.maxExprLen))
+}
+
+// isScaling reports whether the expression is a multiplication or a division
+func isScaling(expr *ast.BinaryExpr) bool {
+	return expr.Op == token.MUL || expr.Op == token.QUO
+}
+
+// isChainOperand reports whether the expression is the left operand of an enclosing multiplication or division, in
+// which case it is checked as part of the enclosing chain
+func isChainOperand(cur inspector.Cursor) bool {
+	node := cur.Node()
+	for parent := cur.Parent(); ; parent = parent.Parent() {
+		switch p := parent.Node().(type) {
+		case *ast.ParenExpr:
+			node = p
+		case *ast.BinaryExpr:
+			return isScaling(p) && p.X == node
+		default:
+			return false
+		}
+	}
+}
+
+// flattenChain returns the leftmost operand of a chain of multiplications and divisions, and the operators and right
+// operands applied to it in order
+func flattenChain(expr *ast.BinaryExpr) (ast.Expr, []token.Token, []ast.Expr) {
+	var ops []token.Token
+	var operands []ast.Expr
+
+	var base ast.Expr = expr
+	for {
+		bin, ok := ast.Unparen(base).(*ast.BinaryExpr)
+		if !ok || !isScaling(bin) {
+			break
+		}
+
+		ops = append([]token.Token{bin.Op}, ops...)
+		operands = append([]ast.Expr{bin.Y}, operands...)
+		base = bin.X
+	}
+
+	return base, ops, operands
+}
+
+func (c *checker) isConstant(expr ast.Expr) bool {
+	return c.pass.TypesInfo.Types[expr].Value != nil
+}
+
+// scaled formats the base multiplied by the factor, expressed in the largest unit dividing it
+func scaled(base ast.Expr, factor constant.Value, qualifier string) string {
+	f, ok := constant.Int64Val(factor)
+	if !ok {
+		return formatOperand(base) + " * " + factor.ExactString()
+	}
+
+	if f == 1 {
+		return formatNode(base)
+	}
+
+	for _, u := range units {
+		if u.value == 1 || f%u.value != 0 {
+			continue
+		}
+
+		if f == u.value {
+			return formatOperand(base) + " * " + qualifier + "." + u.name
+		}
+		return formatOperand(base) + " * " + strconv.FormatInt(f/u.value, 10) + " * " + qualifier + "." + u.name
+	}
+
+	return formatOperand(base) + " * " + factor.String()
+}
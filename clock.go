@@ -0,0 +1,54 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// clockAccessors are the time.Time methods returning a field of the wall clock, only covering the range of the next
+// larger unit
+var clockAccessors = map[string]string{
+	"Nanosecond": "second",
+	"Second":     "minute",
+	"Minute":     "hour",
+	"Hour":       "day",
+}
+
+// checkClockConversion reports clock fields of a time.Time converted to durations, e.g.
+// `time.Duration(time.Now().Nanosecond())`, which is often mistaken for the time since the epoch or used as a jitter
+func (c *checker) checkClockConversion(call *ast.CallExpr) {
+	if !c.classifier.IsConversion(call) {
+		return
+	}
+
+	accessor, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	fn := c.calledFunc(accessor)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "time" {
+		return
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil || !isTimeType(recv.Type()) {
+		return
+	}
+
+	if within, ok := clockAccessors[fn.Name()]; ok {
+		c.reportf(RuleClockField, call, "Conversion of clock field `%s` to duration only covers a %s: use time.Since, UnixNano or math/rand for jitter",
+			c.formatExpr(accessor), within)
+	}
+}
+
+// isTimeType reports whether t is time.Time or a pointer to it
+func isTimeType(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
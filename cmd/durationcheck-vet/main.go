@@ -0,0 +1,21 @@
+// Command durationcheck-vet runs durationcheck as a vet tool, following the protocol of the go command for analysis
+// units, including facts and -json output.
+//
+// Usage:
+//
+//	go vet -vettool=$(which durationcheck-vet) [-durationcheck.flag...] packages...
+package main
+
+import (
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	// unlike under golangci-lint, nothing else applies the nolint directives
+	if err := durationcheck.Analyzer.Flags.Set("nolint", "respect"); err != nil {
+		panic(err)
+	}
+
+	unitchecker.Main(durationcheck.Analyzer)
+}
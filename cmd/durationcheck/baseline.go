@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+const (
+	// generateBaseline is the value of -baseline recording the findings instead of reporting them
+	generateBaseline = "generate"
+	// defaultBaselineFile is the file written by -baseline=generate
+	defaultBaselineFile = "durationcheck-baseline.json"
+)
+
+// baseline records the findings of a legacy codebase so that only new ones are reported
+type baseline struct {
+	Findings []baselineEntry `json:"findings"`
+}
+
+// baselineEntry is a recorded finding. It is matched by its fingerprint, see fingerprint, so that it survives lines
+// shifting. The other fields help reviewing the baseline.
+type baselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	Filename    string `json:"file"`
+	Function    string `json:"function,omitempty"`
+	Message     string `json:"message"`
+	// Count is the number of identical findings, e.g. the same expression repeated in a function
+	Count int `json:"count"`
+}
+
+// writeBaseline records the findings in a baseline file, their files relative to the root of the repository
+func writeBaseline(filename string, findings []finding, root string) error {
+	entries := map[string]*baselineEntry{}
+	for _, f := range findings {
+		if e, ok := entries[f.Fingerprint]; ok {
+			e.Count++
+			continue
+		}
+
+		entries[f.Fingerprint] = &baselineEntry{
+			Fingerprint: f.Fingerprint,
+			Rule:        f.Rule,
+			Filename:    repoPath(root, f.Filename),
+			Function:    f.Function,
+			Message:     f.Message,
+			Count:       1,
+		}
+	}
+
+	b := baseline{Findings: make([]baselineEntry, 0, len(entries))}
+	for _, e := range entries {
+		b.Findings = append(b.Findings, *e)
+	}
+	sort.Slice(b.Findings, func(i, j int) bool {
+		x, y := b.Findings[i], b.Findings[j]
+		if x.Filename != y.Filename {
+			return x.Filename < y.Filename
+		}
+		if x.Function != y.Function {
+			return x.Function < y.Function
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// loadBaseline reads the entries of a baseline file
+func loadBaseline(filename string) ([]baselineEntry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return b.Findings, nil
+}
+
+// baselineCounts returns the number of recorded findings of each fingerprint
+func baselineCounts(entries []baselineEntry) map[string]int {
+	counts := make(map[string]int, len(entries))
+	for _, e := range entries {
+		counts[e.Fingerprint] += max(e.Count, 1)
+	}
+
+	return counts
+}
+
+// applyBaseline removes the findings recorded in the baseline. A fingerprint recorded n times matches its first n
+// findings, so that a copy of a recorded expression in the same function is still reported.
+func applyBaseline(findings []finding, counts map[string]int) []finding {
+	var kept []finding
+	for _, f := range findings {
+		if counts[f.Fingerprint] > 0 {
+			counts[f.Fingerprint]--
+			continue
+		}
+		kept = append(kept, f)
+	}
+
+	return kept
+}
+
+// staleEntries returns the entries of the baseline matching no finding, e.g. fixed since they were recorded, given the
+// counts left by applyBaseline. The count of a stale entry is its number of unmatched findings.
+func staleEntries(entries []baselineEntry, counts map[string]int) []baselineEntry {
+	var stale []baselineEntry
+	for _, e := range entries {
+		n := min(counts[e.Fingerprint], max(e.Count, 1))
+		if n <= 0 {
+			continue
+		}
+		counts[e.Fingerprint] -= n
+
+		e.Count = n
+		stale = append(stale, e)
+	}
+
+	return stale
+}
+
+// writeStaleEntries writes the stale entries of the baseline file, for -stale-baseline
+func writeStaleEntries(w io.Writer, filename string, stale []baselineEntry) {
+	for _, e := range stale {
+		fmt.Fprintf(w, "durationcheck: %s: stale entry %s for %s in %s (%d unmatched): %s\n",
+			filename, e.Fingerprint, e.Rule, e.Filename, e.Count, e.Message)
+	}
+}
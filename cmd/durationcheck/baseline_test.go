@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, defaultBaselineFile)
+
+	mul := func(line int, function string) finding {
+		f := finding{Rule: "mul", Filename: filepath.Join(dir, "a.go"), Line: line, Function: function, Expression: "d * d"}
+		f.Fingerprint = fingerprint(f, "a.go")
+		return f
+	}
+	bitwise := finding{Rule: "bitwise", Filename: filepath.Join(dir, "b.go"), Line: 3, Function: "g", Expression: "d & m"}
+	bitwise.Fingerprint = fingerprint(bitwise, "b.go")
+
+	if err := writeBaseline(filename, []finding{mul(10, "f"), mul(12, "f"), bitwise}, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"file": "a.go"`) {
+		t.Errorf("expected the files relative to the root, got %s", data)
+	}
+
+	entries, err := loadBaseline(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := baselineCounts(entries)
+
+	// the lines shifted, the expression was repeated once more in f and a new function multiplies durations
+	shifted := bitwise
+	shifted.Line = 5
+	findings := []finding{mul(20, "f"), mul(22, "f"), mul(24, "f"), mul(30, "h"), shifted}
+
+	want := []finding{findings[2], findings[3]}
+	if got := applyBaseline(findings, counts); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestStaleEntries(t *testing.T) {
+	entries := []baselineEntry{
+		{Fingerprint: "0123456789abcdef", Rule: "mul", Filename: "a.go", Function: "f", Message: "Multiplication of durations: `d * d`", Count: 3},
+		{Fingerprint: "fedcba9876543210", Rule: "bitwise", Filename: "b.go", Function: "g", Message: "Bitwise operation on durations: `d & m`", Count: 1},
+		{Fingerprint: "00112233aabbccdd", Rule: "mul", Filename: "c.go", Message: "Multiplication of durations: `x * y`", Count: 1},
+	}
+	counts := baselineCounts(entries)
+
+	// one of the three products of f was fixed, and so was the bitwise operation of g
+	findings := []finding{{Fingerprint: "0123456789abcdef"}, {Fingerprint: "0123456789abcdef"}, {Fingerprint: "00112233aabbccdd"}}
+	if kept := applyBaseline(findings, counts); len(kept) != 0 {
+		t.Fatalf("unexpected findings %v", kept)
+	}
+
+	stale := staleEntries(entries, counts)
+	want := []baselineEntry{entries[0], entries[1]}
+	want[0].Count = 1
+	if !reflect.DeepEqual(stale, want) {
+		t.Fatalf("got %v, want %v", stale, want)
+	}
+
+	var buf bytes.Buffer
+	writeStaleEntries(&buf, defaultBaselineFile, stale)
+	if got, want := buf.String(), "durationcheck: durationcheck-baseline.json: stale entry fedcba9876543210 for bitwise in b.go (1 unmatched): Bitwise operation on durations: `d & m`\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("got %q, want it to end with %q", got, want)
+	}
+}
+
+func TestLoadBaselineInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), defaultBaselineFile)
+	writeFile(t, filename, "0123456789abcdef\n")
+
+	if _, err := loadBaseline(filename); err == nil {
+		t.Error("expected an error for a baseline that isn't JSON")
+	}
+
+	if _, err := loadBaseline(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("got %v for a missing baseline, want a not exist error", err)
+	}
+}
+
+// TestBaselineShiftedLines checks that the findings recorded in a baseline still match after lines are inserted above
+// them, which changes the positions of their operands
+func TestBaselineShiftedLines(t *testing.T) {
+	dir := t.TempDir()
+	source := `package m
+
+import "time"
+
+func f(timeout time.Duration) time.Duration {
+	elapsed := timeout
+	return elapsed * time.Second
+}
+`
+	findings := analyzeModule(t, dir, map[string]string{"m.go": source})
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %v", len(findings), findings)
+	}
+
+	filename := filepath.Join(dir, defaultBaselineFile)
+	if err := writeBaseline(filename, findings, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	shifted := analyzeModule(t, dir, map[string]string{"m.go": strings.Replace(source, "func f", "// f scales the timeout\nfunc f", 1)})
+	if len(shifted) != 1 || shifted[0].Line != findings[0].Line+1 {
+		t.Fatalf("got %v, want the finding one line below", shifted)
+	}
+
+	entries, err := loadBaseline(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kept := applyBaseline(shifted, baselineCounts(entries)); len(kept) != 0 {
+		t.Errorf("the baseline doesn't match the shifted findings: %v", kept)
+	}
+}
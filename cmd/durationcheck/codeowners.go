@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charithe/durationcheck/internal/pathmatch"
+)
+
+// codeownersLocations are the locations where GitHub and GitLab look for a CODEOWNERS file, relative to the root of
+// the repository
+var codeownersLocations = []string{"CODEOWNERS", filepath.Join(".github", "CODEOWNERS"), filepath.Join("docs", "CODEOWNERS")}
+
+// codeowners maps files to their owners
+type codeowners struct {
+	// root is the directory the patterns are relative to
+	root  string
+	rules []ownerRule
+}
+
+type ownerRule struct {
+	pattern string
+	owners  []string
+}
+
+// findCodeowners loads the CODEOWNERS file at one of the usual locations in dir, if any
+func findCodeowners(dir string) (*codeowners, error) {
+	for _, loc := range codeownersLocations {
+		filename := filepath.Join(dir, loc)
+		if _, err := os.Stat(filename); err == nil {
+			return loadCodeowners(filename)
+		}
+	}
+
+	return nil, nil
+}
+
+// loadCodeowners loads a CODEOWNERS file. Its patterns are relative to the directory containing it, or to the parent
+// directory for files in .github or docs.
+func loadCodeowners(filename string) (*codeowners, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	root := filepath.Dir(abs)
+	if base := filepath.Base(root); base == ".github" || base == "docs" {
+		root = filepath.Dir(root)
+	}
+
+	rules, err := parseCodeowners(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return &codeowners{root: root, rules: rules}, nil
+}
+
+func parseCodeowners(r io.Reader) ([]ownerRule, error) {
+	var rules []ownerRule
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// GitLab sections, e.g. `[Documentation]`, only group the rules
+		if strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+			continue
+		}
+
+		if err := pathmatch.Validate(fields[0]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rules = append(rules, ownerRule{pattern: fields[0], owners: fields[1:]})
+	}
+
+	return rules, scanner.Err()
+}
+
+// owners returns the owners of the file, those of the last matching rule
+func (c *codeowners) owners(filename string) []string {
+	if c == nil {
+		return nil
+	}
+
+	rel, err := filepath.Rel(c.root, filename)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if matchOwnerPattern(c.rules[i].pattern, rel) {
+			return c.rules[i].owners
+		}
+	}
+
+	return nil
+}
+
+// matchOwnerPattern matches like gitignore: a pattern containing a slash other than a trailing one is relative to the
+// root, and a pattern that doesn't end with a slash also matches the files below the directories it names
+func matchOwnerPattern(pattern, filename string) bool {
+	if strings.Contains(strings.TrimSuffix(pattern, "/"), "/") && !strings.HasPrefix(pattern, "/") {
+		pattern = "/" + pattern
+	}
+
+	if pathmatch.Match(pattern, filename) {
+		return true
+	}
+
+	return !strings.HasSuffix(pattern, "/") && pathmatch.Match(pattern+"/", filename)
+}
+
+// unowned groups the findings of files without owners
+const unowned = "(unowned)"
+
+// writeByOwner writes the findings in text format grouped by owner, a finding with several owners being listed for
+// each of them
+func writeByOwner(w io.Writer, findings []finding) error {
+	groups := map[string][]finding{}
+	for _, f := range findings {
+		if len(f.Owners) == 0 {
+			groups[unowned] = append(groups[unowned], f)
+		}
+		for _, owner := range f.Owners {
+			groups[owner] = append(groups[owner], f)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if name != unowned {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[unowned]; ok {
+		names = append(names, unowned)
+	}
+
+	for i, name := range names {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "%s (%d):\n", name, len(groups[name])); err != nil {
+			return err
+		}
+
+		for _, f := range groups[name] {
+			if _, err := fmt.Fprintf(w, "  %s\n", f); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
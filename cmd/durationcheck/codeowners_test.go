@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCodeowners(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".github", "CODEOWNERS"), `# default owners
+*             @org/core
+
+[Docs]
+/docs/        @org/docs
+*.md          @org/docs
+pkg/api       @org/api dev@example.com
+**/testdata/  @org/qa
+vendor/
+`)
+
+	co, err := findCodeowners(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]string{
+		"main.go":                    "@org/core",
+		"docs/guide.go":              "@org/docs",
+		"pkg/docs/doc.go":            "@org/core",
+		"pkg/README.md":              "@org/docs",
+		"pkg/api/client.go":          "@org/api dev@example.com",
+		"pkg/api/v2/client.go":       "@org/api dev@example.com",
+		"internal/pkg/api/client.go": "@org/core",
+		"pkg/api/testdata/a.go":      "@org/qa",
+		"vendor/example.com/m/m.go":  "",
+	}
+
+	for filename, want := range testCases {
+		if got := strings.Join(co.owners(filepath.Join(dir, filename)), " "); got != want {
+			t.Errorf("owners(%q) = %q, want %q", filename, got, want)
+		}
+	}
+
+	if got := co.owners(filepath.Join(filepath.Dir(dir), "elsewhere.go")); got != nil {
+		t.Errorf("owners of a file outside the repository = %q, want none", got)
+	}
+}
+
+func TestFindCodeownersMissing(t *testing.T) {
+	co, err := findCodeowners(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := co.owners("/a.go"); got != nil {
+		t.Errorf("owners without CODEOWNERS = %q, want none", got)
+	}
+}
+
+func TestWriteByOwner(t *testing.T) {
+	findings := []finding{
+		{Filename: "a.go", Line: 1, Column: 1, Message: "first", Owners: []string{"@org/b", "@org/a"}},
+		{Filename: "b.go", Line: 2, Column: 1, Message: "second"},
+		{Filename: "c.go", Line: 3, Column: 1, Message: "third", Owners: []string{"@org/b"}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeByOwner(&buf, findings); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `@org/a (1):
+  a.go:1:1: first
+
+@org/b (2):
+  a.go:1:1: first
+  c.go:3:1: third
+
+(unowned) (1):
+  b.go:2:1: second
+`
+
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".durationcheck.yml"), "enable: [names]\n")
+	writeFile(t, filepath.Join(root, "repo", ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(root, "repo", "module", ".durationcheck.yaml"), "enable: [bitwise]\n")
+
+	pkg := filepath.Join(root, "repo", "module", "pkg")
+	if err := os.MkdirAll(pkg, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]string{
+		pkg:                                   filepath.Join(root, "repo", "module", ".durationcheck.yaml"),
+		filepath.Join(root, "repo", "module"): filepath.Join(root, "repo", "module", ".durationcheck.yaml"),
+		// the search stops at the root of the repository
+		filepath.Join(root, "repo"): "",
+		root:                        filepath.Join(root, ".durationcheck.yml"),
+	}
+
+	for dir, want := range testCases {
+		got, err := findConfig(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Errorf("findConfig(%q) = %q, want %q", dir, got, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// reportDiff holds the findings introduced and resolved between two reports
+type reportDiff struct {
+	Introduced []finding `json:"introduced"`
+	Resolved   []finding `json:"resolved"`
+}
+
+// runDiff implements `durationcheck diff [-format text|json] old.json new.json`
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "text", "output format: text or json")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: durationcheck diff [-format text|json] old.json new.json\n")
+		fs.PrintDefaults()
+	}
+
+	filenames, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(filenames) != 2 || *format != "text" && *format != "json" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	old, err := readReport(filenames[0])
+	if err != nil {
+		return err
+	}
+
+	current, err := readReport(filenames[1])
+	if err != nil {
+		return err
+	}
+
+	d := diffReports(old, current)
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+
+	return d.writeText(os.Stdout)
+}
+
+// diffReports compares the findings of two reports by fingerprint. A fingerprint present n times in the old report
+// and m times in the new one counts as m-n introduced findings if m > n, or n-m resolved ones otherwise.
+func diffReports(old, current *report) reportDiff {
+	d := reportDiff{Introduced: []finding{}, Resolved: []finding{}}
+
+	d.Introduced = append(d.Introduced, subtract(current.Findings, old.Findings)...)
+	d.Resolved = append(d.Resolved, subtract(old.Findings, current.Findings)...)
+
+	d.Introduced = sortFindings(d.Introduced)
+	d.Resolved = sortFindings(d.Resolved)
+
+	return d
+}
+
+// subtract returns the findings of a whose fingerprints are not matched by a finding of b
+func subtract(a, b []finding) []finding {
+	counts := make(map[string]int, len(b))
+	for _, f := range b {
+		counts[f.Fingerprint]++
+	}
+
+	var remaining []finding
+	for _, f := range a {
+		if counts[f.Fingerprint] > 0 {
+			counts[f.Fingerprint]--
+			continue
+		}
+
+		remaining = append(remaining, f)
+	}
+
+	return remaining
+}
+
+func (d reportDiff) writeText(w io.Writer) error {
+	sections := []struct {
+		title    string
+		findings []finding
+	}{
+		{title: "Introduced", findings: d.Introduced},
+		{title: "Resolved", findings: d.Resolved},
+	}
+
+	for _, section := range sections {
+		if len(section.findings) == 0 {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s:\n", section.title); err != nil {
+			return err
+		}
+
+		for _, f := range section.findings {
+			if _, err := fmt.Fprintf(w, "  %s\n", f); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d introduced, %d resolved\n", len(d.Introduced), len(d.Resolved))
+	return err
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDiffReports(t *testing.T) {
+	kept := finding{Rule: "mul", Filename: "a.go", Line: 3, Column: 6, Message: "Multiplication of durations: `d * d`", Function: "f", Expression: "d * d"}
+	kept.Fingerprint = fingerprint(kept, kept.Filename)
+
+	// the kept finding moved down but its fingerprint doesn't depend on the line
+	moved := kept
+	moved.Line = 8
+
+	resolved := finding{Rule: "mul", Filename: "a.go", Line: 5, Column: 6, Message: "Multiplication of durations: `x * y`", Function: "f", Expression: "x * y"}
+	resolved.Fingerprint = fingerprint(resolved, resolved.Filename)
+
+	introduced := finding{Rule: "bitwise", Filename: "b.go", Line: 1, Column: 2, Message: "Bitwise operation on durations: `d & 1`", Function: "g", Expression: "d & 1"}
+	introduced.Fingerprint = fingerprint(introduced, introduced.Filename)
+
+	d := diffReports(
+		&report{Findings: []finding{kept, resolved}},
+		&report{Findings: []finding{introduced, moved}},
+	)
+
+	var buf bytes.Buffer
+	if err := d.writeText(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Introduced:\n" +
+		"  b.go:1:2: Bitwise operation on durations: `d & 1`\n" +
+		"Resolved:\n" +
+		"  a.go:5:6: Multiplication of durations: `x * y`\n" +
+		"1 introduced, 1 resolved\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
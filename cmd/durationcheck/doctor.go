@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/types"
+	"go/version"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/packages"
+)
+
+// goEnv holds the `go env` variables the doctor looks at
+type goEnv struct {
+	GOVERSION   string
+	GOMOD       string
+	GOFLAGS     string
+	GO111MODULE string
+}
+
+// runDoctor implements `durationcheck doctor [-tags tags] [-test] [packages...]`, which checks the environment and
+// explains why a run on the packages would report nothing
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	tags := fs.String("tags", "", "comma-separated build tags to load the packages with")
+	withTests := fs.Bool("test", true, "also load test files")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: durationcheck doctor [-tags tags] [-test] [packages...]\n")
+		fs.PrintDefaults()
+	}
+
+	patterns, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	env, err := readGoEnv("")
+	if err != nil {
+		return err
+	}
+
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Tests: *withTests}
+	if *tags != "" {
+		cfg.BuildFlags = []string{"-tags=" + *tags}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+
+	if n := diagnose(os.Stdout, env, *tags, pkgs); n > 0 {
+		return fmt.Errorf("%d problem(s) found", n)
+	}
+
+	return nil
+}
+
+// readGoEnv runs `go env -json` in the directory, the working directory if empty
+func readGoEnv(dir string) (goEnv, error) {
+	var env goEnv
+
+	cmd := exec.Command("go", "env", "-json", "GOVERSION", "GOMOD", "GOFLAGS", "GO111MODULE")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return env, fmt.Errorf("go env: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	if err := json.Unmarshal(out, &env); err != nil {
+		return env, fmt.Errorf("go env: %w", err)
+	}
+
+	return env, nil
+}
+
+// maxDoctorErrors is the number of package errors the doctor lists
+const maxDoctorErrors = 10
+
+// diagnose writes one line per check of the environment and of the loaded packages, followed by the reasons why a
+// run would report nothing, if any. It returns the number of problems found.
+func diagnose(w io.Writer, env goEnv, tags string, pkgs []*packages.Package) int {
+	var problems []string
+	checkf := func(ok bool, problem, format string, args ...interface{}) {
+		status := "ok  "
+		if !ok {
+			status = "FAIL"
+			problems = append(problems, problem)
+		}
+		fmt.Fprintf(w, "%s %s\n", status, fmt.Sprintf(format, args...))
+	}
+	notef := func(format string, args ...interface{}) {
+		fmt.Fprintf(w, "     %s\n", fmt.Sprintf(format, args...))
+	}
+
+	notef("durationcheck built with %s for %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	checkf(env.GOVERSION != "", "the go command was not found", "go toolchain: %s", valueOr(env.GOVERSION, "not found"))
+	if env.GOVERSION != "" && version.Compare(env.GOVERSION, runtime.Version()) > 0 {
+		notef("the toolchain is newer than durationcheck: rebuild it if the packages fail to type check")
+	}
+
+	checkf(moduleMode(env) != "", "packages are resolved in GOPATH mode, run from within a module",
+		"module mode: %s", valueOr(moduleMode(env), "off"))
+
+	switch {
+	case tags != "":
+		notef("build tags: %s", tags)
+	case strings.Contains(env.GOFLAGS, "-tags"):
+		notef("build tags from GOFLAGS: %s", env.GOFLAGS)
+	default:
+		notef("build tags: none, files behind build constraints are not analyzed (see -tags)")
+	}
+
+	var broken, withDurations, ignored, listed int
+	for _, pkg := range pkgs {
+		ignored += len(pkg.IgnoredFiles)
+		if len(pkg.Errors) > 0 {
+			broken++
+			for _, err := range pkg.Errors {
+				if listed++; listed <= maxDoctorErrors {
+					notef("%s", err)
+				}
+			}
+			continue
+		}
+
+		if pkg.TypesInfo != nil && usesDurations(pkg.TypesInfo) {
+			withDurations++
+		}
+	}
+
+	checkf(len(pkgs) > 0, "the patterns match no package", "packages matched: %d", len(pkgs))
+	checkf(broken == 0, "packages that don't type check are not analyzed, fix the errors above",
+		"packages type checked: %d of %d", len(pkgs)-broken, len(pkgs))
+	if ignored > 0 {
+		notef("files excluded by build constraints: %d", ignored)
+	}
+	checkf(withDurations > 0, "no package uses time.Duration, so the analyzer skips them all unless a rule looking for integers that should be durations (-int-params) is enabled",
+		"packages using time.Duration: %d of %d", withDurations, len(pkgs)-broken)
+
+	if len(problems) == 0 {
+		fmt.Fprintf(w, "\nno problem found: if nothing is reported, the packages have no finding for the enabled rules (see the opt-in flags)\n")
+		return 0
+	}
+
+	fmt.Fprintf(w, "\nlikely reasons why nothing is reported:\n")
+	for _, p := range problems {
+		fmt.Fprintf(w, "  - %s\n", p)
+	}
+
+	return len(problems)
+}
+
+// usesDurations mirrors the fast path of the analyzer, which skips the packages without an expression of type
+// time.Duration
+func usesDurations(info *types.Info) bool {
+	for _, tv := range info.Types {
+		if durationexpr.IsDuration(tv.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func moduleMode(env goEnv) string {
+	if env.GOMOD == "" || env.GOMOD == os.DevNull || env.GO111MODULE == "off" {
+		return ""
+	}
+
+	return env.GOMOD
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+
+	return s
+}
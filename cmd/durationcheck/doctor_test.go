@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestDiagnose(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/m\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(dir, "m.go"), "package m\n\nfunc Double(n int) int { return n * 2 }\n")
+	writeFile(t, filepath.Join(dir, "broken", "broken.go"), "package broken\n\nvar x int = \"x\"\n")
+	writeFile(t, filepath.Join(dir, "m_integration.go"), "//go:build integration\n\npackage m\n\nimport \"time\"\n\nvar d = time.Second\n")
+
+	env, err := readGoEnv(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if n := diagnose(&buf, env, "", pkgs); n != 2 {
+		t.Errorf("got %d problems, want 2:\n%s", n, buf.String())
+	}
+
+	for _, want := range []string{
+		"ok   module mode: " + filepath.Join(dir, "go.mod"),
+		"FAIL packages type checked: 1 of 2",
+		"files excluded by build constraints: 1",
+		"FAIL packages using time.Duration: 0 of 1",
+		"  - no package uses time.Duration",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output doesn't contain %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvHeader = []string{"rule", "severity", "confidence", "file", "line", "column", "function", "message", "expression", "fingerprint", "owners"}
+
+func writeCSV(w io.Writer, findings []finding) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, f := range findings {
+		record := []string{f.Rule, f.Severity, f.Confidence, f.Filename, strconv.Itoa(f.Line), strconv.Itoa(f.Column), f.Function,
+			f.Message, f.Expression, f.Fingerprint, strings.Join(f.Owners, " ")}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// runTime is the timestamp recorded with the findings of this run in SQL exports
+var runTime = time.Now
+
+// writeSQL writes SQL statements appending the findings, with the time of the run, to a findings table. They are meant
+// to be piped to a database shell such as sqlite3, which avoids depending on a database driver:
+//
+//	durationcheck -format=sql ./... | sqlite3 findings.db
+func writeSQL(w io.Writer, findings []finding) error {
+	var b strings.Builder
+
+	b.WriteString("BEGIN TRANSACTION;\n")
+	b.WriteString("CREATE TABLE IF NOT EXISTS findings (\n" +
+		"  run_at TEXT NOT NULL,\n" +
+		"  rule TEXT NOT NULL,\n" +
+		"  severity TEXT NOT NULL,\n" +
+		"  confidence TEXT NOT NULL,\n" +
+		"  file TEXT NOT NULL,\n" +
+		"  line INTEGER NOT NULL,\n" +
+		"  column INTEGER NOT NULL,\n" +
+		"  function TEXT NOT NULL,\n" +
+		"  message TEXT NOT NULL,\n" +
+		"  expression TEXT NOT NULL,\n" +
+		"  fingerprint TEXT NOT NULL\n" +
+		");\n")
+
+	runAt := runTime().UTC().Format(time.RFC3339)
+	for _, f := range findings {
+		fmt.Fprintf(&b, "INSERT INTO findings VALUES (%s, %s, %s, %s, %s, %d, %d, %s, %s, %s, %s);\n",
+			sqlQuote(runAt), sqlQuote(f.Rule), sqlQuote(f.Severity), sqlQuote(f.Confidence), sqlQuote(f.Filename), f.Line, f.Column,
+			sqlQuote(f.Function), sqlQuote(f.Message), sqlQuote(f.Expression), sqlQuote(f.Fingerprint))
+	}
+
+	b.WriteString("COMMIT;\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sqlQuote quotes a string as an SQL literal
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
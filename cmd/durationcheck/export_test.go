@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+var exportFindings = []finding{{
+	Rule:        "mul",
+	Filename:    "a.go",
+	Line:        3,
+	Column:      6,
+	Function:    "f",
+	Message:     "Multiplication of durations: `d * time.Second`, isn't it?",
+	Expression:  "d * time.Second",
+	Severity:    "warning",
+	Confidence:  "certain",
+	Fingerprint: "0123456789abcdef",
+	Owners:      []string{"@org/team", "dev@example.com"},
+}}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, exportFindings); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "rule,severity,confidence,file,line,column,function,message,expression,fingerprint,owners\n" +
+		"mul,warning,certain,a.go,3,6,f,\"Multiplication of durations: `d * time.Second`, isn't it?\",d * time.Second,0123456789abcdef,@org/team dev@example.com\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteSQL(t *testing.T) {
+	runTime = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { runTime = time.Now })
+
+	var buf bytes.Buffer
+	if err := writeSQL(&buf, exportFindings); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "INSERT INTO findings VALUES ('2024-05-01T12:00:00Z', 'mul', 'warning', 'certain', 'a.go', 3, 6, 'f', " +
+		"'Multiplication of durations: `d * time.Second`, isn''t it?', 'd * time.Second', '0123456789abcdef');\n"
+
+	if got := buf.String(); !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Errorf("got:\n%s\nwant it to contain:\n%s", got, want)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	findings := []finding{
+		{Rule: "mul", Filename: "pkg/a.go", Line: 3, Column: 6, EndLine: 3, EndColumn: 22, Message: "Multiplication of durations: `d * time.Second`", Expression: "d * time.Second", Fingerprint: "0123456789abcdef"},
+		{Rule: "bitwise", Filename: "/src/b.go", Line: 7, Column: 2, Message: "Bitwise operation", Severity: "info", Fingerprint: "fedcba9876543210"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, findings); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`"version": "2.1.0"`,
+		`"rules": [
+            {
+              "id": "bitwise",
+              "helpUri": "https://github.com/charithe/durationcheck/blob/master/docs/rules.md#bitwise"
+            },
+            {
+              "id": "mul",
+              "helpUri": "https://github.com/charithe/durationcheck/blob/master/docs/rules.md#mul"
+            }
+          ]`,
+		`"ruleId": "mul",
+          "level": "error",`,
+		`"uri": "pkg/a.go"`,
+		`"region": {
+                  "startLine": 3,
+                  "startColumn": 6,
+                  "endLine": 3,
+                  "endColumn": 22
+                }`,
+		`"durationcheck/v1": "0123456789abcdef"
+          },
+          "properties": {
+            "expression": "d * time.Second"
+          }`,
+		`"level": "note"`,
+		`"uri": "file:///src/b.go"`,
+	} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("got:\n%s\nwant it to contain:\n%s", buf.String(), want)
+		}
+	}
+}
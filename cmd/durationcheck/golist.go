@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// listedPackage holds the fields of a `go list -json` record needed to type check the package
+type listedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	CgoFiles   []string
+	Imports    []string
+	ImportMap  map[string]string
+	DepOnly    bool
+	Module     *struct {
+		GoVersion string
+	}
+	Error *struct {
+		Err string
+	}
+}
+
+// loadGoList builds the packages described by the output of `go list -deps -json` instead of invoking go list again.
+// Up to workers packages are type checked concurrently, each one once all of its dependencies are. Only the
+// packages that were not listed as dependencies are returned, with their syntax and type information.
+func loadGoList(filename string, workers int) ([]*packages.Package, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	listed, err := decodeGoList(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	fset := token.NewFileSet()
+	sizes := types.SizesFor("gc", runtime.GOARCH)
+
+	type entry struct {
+		lp   *listedPackage
+		pkg  *packages.Package
+		done chan struct{}
+	}
+
+	var entries []*entry
+	byPath := make(map[string]*entry, len(listed))
+	for _, lp := range listed {
+		// the generated main packages of test binaries are of no interest
+		if strings.HasSuffix(lp.ImportPath, ".test") {
+			continue
+		}
+
+		if lp.Error != nil && len(lp.GoFiles)+len(lp.CgoFiles) == 0 {
+			return nil, fmt.Errorf("%s: %s", lp.ImportPath, lp.Error.Err)
+		}
+
+		e := &entry{lp: lp, done: make(chan struct{})}
+		entries = append(entries, e)
+		byPath[lp.ImportPath] = e
+	}
+
+	// lookup is only called for the dependencies of a package, once they are type checked
+	lookup := func(path string) *packages.Package {
+		if e, ok := byPath[path]; ok {
+			return e.pkg
+		}
+		return nil
+	}
+
+	sem := make(chan struct{}, max(workers, 1))
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(e.done)
+
+			for _, imp := range e.lp.Imports {
+				if dep, ok := byPath[imp]; ok {
+					<-dep.done
+				}
+			}
+
+			sem <- struct{}{}
+			e.pkg = typeCheck(fset, sizes, e.lp, lookup, !e.lp.DepOnly)
+			<-sem
+		}()
+	}
+	wg.Wait()
+
+	var roots []*packages.Package
+	for _, e := range entries {
+		if !e.lp.DepOnly {
+			roots = append(roots, e.pkg)
+		}
+	}
+
+	return roots, nil
+}
+
+func decodeGoList(r io.Reader) ([]*listedPackage, error) {
+	var listed []*listedPackage
+
+	dec := json.NewDecoder(r)
+	for {
+		lp := &listedPackage{}
+		if err := dec.Decode(lp); err != nil {
+			if errors.Is(err, io.EOF) {
+				return listed, nil
+			}
+			return nil, err
+		}
+
+		listed = append(listed, lp)
+	}
+}
+
+// typeCheck parses and type checks a listed package, resolving its imports among the already loaded packages.
+// Type information is only recorded for the packages that are analyzed.
+func typeCheck(fset *token.FileSet, sizes types.Sizes, lp *listedPackage, lookup func(string) *packages.Package, full bool) *packages.Package {
+	pkg := &packages.Package{
+		ID:         lp.ImportPath,
+		Name:       lp.Name,
+		PkgPath:    pkgPath(lp.ImportPath),
+		Fset:       fset,
+		TypesSizes: sizes,
+		Imports:    make(map[string]*packages.Package),
+	}
+
+	mode := parser.SkipObjectResolution
+	if full {
+		mode |= parser.ParseComments
+	}
+
+	for _, name := range append(append([]string(nil), lp.GoFiles...), lp.CgoFiles...) {
+		filename := filepath.Join(lp.Dir, name)
+		pkg.GoFiles = append(pkg.GoFiles, filename)
+
+		file, err := parser.ParseFile(fset, filename, nil, mode)
+		if file != nil {
+			pkg.Syntax = append(pkg.Syntax, file)
+		}
+		if err != nil {
+			pkg.Errors = append(pkg.Errors, packages.Error{Msg: err.Error(), Kind: packages.ParseError})
+		}
+	}
+	pkg.CompiledGoFiles = pkg.GoFiles
+
+	if full {
+		pkg.TypesInfo = &types.Info{
+			Types:        make(map[ast.Expr]types.TypeAndValue),
+			Defs:         make(map[*ast.Ident]types.Object),
+			Uses:         make(map[*ast.Ident]types.Object),
+			Implicits:    make(map[ast.Node]types.Object),
+			Instances:    make(map[*ast.Ident]types.Instance),
+			Scopes:       make(map[ast.Node]*types.Scope),
+			Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+			FileVersions: make(map[*ast.File]string),
+		}
+	}
+
+	conf := &types.Config{
+		Sizes:       sizes,
+		FakeImportC: len(lp.CgoFiles) > 0,
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "unsafe" {
+				return types.Unsafe, nil
+			}
+
+			if mapped, ok := lp.ImportMap[path]; ok {
+				path = mapped
+			}
+
+			dep := lookup(path)
+			if dep == nil || dep.Types == nil {
+				return nil, fmt.Errorf("package %s is missing from the go list output", path)
+			}
+
+			pkg.Imports[dep.PkgPath] = dep
+			return dep.Types, nil
+		}),
+		Error: func(err error) {
+			pkg.TypeErrors = append(pkg.TypeErrors, err.(types.Error))
+			pkg.IllTyped = true
+		},
+	}
+
+	if lp.Module != nil && lp.Module.GoVersion != "" {
+		conf.GoVersion = "go" + lp.Module.GoVersion
+	}
+
+	pkg.Types, _ = conf.Check(pkg.PkgPath, fset, pkg.Syntax, pkg.TypesInfo)
+
+	if full {
+		for _, err := range pkg.TypeErrors {
+			pkg.Errors = append(pkg.Errors, packages.Error{Pos: err.Fset.Position(err.Pos).String(), Msg: err.Msg, Kind: packages.TypeError})
+		}
+	} else {
+		// the syntax of dependencies is only needed to type check them
+		pkg.Syntax = nil
+	}
+
+	if len(pkg.Errors) > 0 {
+		pkg.IllTyped = true
+	}
+
+	return pkg
+}
+
+// pkgPath strips the test variant suffix from an import path, e.g. `a [a.test]`
+func pkgPath(importPath string) string {
+	path, _, _ := strings.Cut(importPath, " ")
+	return path
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGoList(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/m\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(dir, "m.go"), `package m
+
+import (
+	"time"
+
+	"example.com/m/sub"
+)
+
+func f(d time.Duration) time.Duration { return d * sub.Timeout }
+`)
+	writeFile(t, filepath.Join(dir, "sub", "sub.go"), `package sub
+
+import "time"
+
+const Timeout = 10 * time.Second
+`)
+
+	cmd := exec.Command("go", "list", "-deps", "-json", "./...")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listFile := filepath.Join(dir, "packages.json")
+	writeFile(t, listFile, string(out))
+
+	pkgs, err := loadGoList(listFile, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pkgs) != 2 {
+		t.Fatalf("got %d packages, want 2", len(pkgs))
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			t.Fatalf("%s: unexpected errors: %v", pkg.PkgPath, pkg.Errors)
+		}
+	}
+
+	findings, err := analyze(pkgs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 1 || findings[0].Line != 9 {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func writeFile(t *testing.T, filename, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,10 +1,401 @@
+// Command durationcheck reports multiplications of durations and other suspicious duration arithmetic.
+//
+// Usage:
+//
+//	durationcheck [flags] packages...
+//	durationcheck [flags] -stdin -stdin-filename=file.go < file.go
+//	durationcheck merge [-o output] reports...
+//	durationcheck diff [-format text|json] old.json new.json
+//	durationcheck doctor [-tags tags] [-test] [packages...]
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
 	"github.com/charithe/durationcheck"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	tests         = flag.Bool("test", true, "also analyze test files")
+	trimPath      = flag.Bool("trimpath", false, "report paths relative to the working directory, module cache, GOPATH, GOROOT or home directory")
+	workers       = flag.Int("j", runtime.NumCPU(), "number of packages analyzed concurrently, and of threads loading them")
+	format        = flag.String("format", "text", "output format: text, json, sarif, csv or sql (SQL statements for a database shell such as sqlite3)")
+	goList        = flag.String("go-list", "", "read the packages to analyze from the output of `go list -deps -json` in this file instead of loading them")
+	owners        = flag.String("codeowners", "", "CODEOWNERS file attributing findings to their owners (default: CODEOWNERS, .github/CODEOWNERS or docs/CODEOWNERS if present)")
+	groupBy       = flag.String("group-by", "", "group findings in text output: owner")
+	train         = flag.Bool("train", false, "print the configuration entries accepting the findings of a reviewed codebase instead of reporting them")
+	suppressFile  = flag.String("suppress", "", "file listing the fingerprints of findings to suppress, one per line")
+	severities    = flag.String("severity", "", "comma-separated severities of rules overriding the configuration, e.g. names=warning,bitwise=info (severities: error, warning, info)")
+	failOn        = flag.String("fail-on", durationcheck.SeverityInfo, "least severe findings making the command exit with status 3: error, warning, info or none")
+	maxIssues     = flag.Int("max-issues", 0, "maximum number of findings written, the others being counted on stderr (0 means no limit)")
+	stdin         = flag.Bool("stdin", false, "analyze the content of stdin as the file named by -stdin-filename, in its package, e.g. the unsaved buffer of an editor")
+	stdinName     = flag.String("stdin-filename", "", "name of the file read from stdin with -stdin")
+	stats         = flag.Bool("stats", false, "print the analysis time and the number of findings of each package to stderr")
+	baselineFile  = flag.String("baseline", "", "baseline file whose recorded findings are not reported, or generate to record the findings in "+defaultBaselineFile)
+	staleBaseline = flag.Bool("stale-baseline", false, "with -baseline, list on stderr the baseline entries matching no finding")
 )
 
+// trainer records the findings of trainingAnalyzer with -train
+var trainer = durationcheck.NewTrainer()
+
+// trainingAnalyzer is run instead of durationcheck.Analyzer with -train, recording the findings in trainer instead of
+// reporting them. Its flags are set along with those of durationcheck.Analyzer, see teeValue.
+var trainingAnalyzer = durationcheck.NewAnalyzer(durationcheck.WithTrainer(trainer))
+
+// analyzer is the analyzer run over the packages
+var analyzer = durationcheck.Analyzer
+
+// trainMinCount is the number of findings a function or an identifier must be involved in to be suggested by -train
+const trainMinCount = 2
+
+// subcommands operate on reports instead of analyzing packages
+var subcommands = map[string]func(args []string) error{
+	"merge":  runMerge,
+	"diff":   runDiff,
+	"doctor": runDoctor,
+}
+
 func main() {
-	singlechecker.Main(durationcheck.Analyzer)
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s\n\nusage: durationcheck [flags] packages...\n       durationcheck [flags] -stdin -stdin-filename=file.go\n       durationcheck merge [-o output] reports...\n       durationcheck diff [-format text|json] old.json new.json\n       durationcheck doctor [-tags tags] [-test] [packages...]\n\n", durationcheck.Analyzer.Doc)
+		flag.PrintDefaults()
+	}
+
+	// analyzer flags are registered without a prefix, like singlechecker does
+	durationcheck.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		flag.Var(teeValue{f.Value, trainingAnalyzer.Flags.Lookup(f.Name).Value}, f.Name, f.Usage)
+	})
+
+	// unlike under golangci-lint, nothing else applies the nolint directives
+	nolint := flag.Lookup("nolint")
+	if err := nolint.Value.Set("respect"); err != nil {
+		panic(err)
+	}
+	nolint.DefValue = "respect"
+
+	flag.Parse()
+
+	if flag.NArg() == 0 && *goList == "" && !*stdin {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *stdin && (*stdinName == "" || flag.NArg() > 0 || *goList != "") {
+		fmt.Fprintf(os.Stderr, "durationcheck: -stdin requires -stdin-filename and no packages\n")
+		os.Exit(2)
+	}
+
+	// the configuration file of the repository applies unless -config names another one
+	if config := flag.Lookup("config"); config.Value.String() == "" {
+		filename, err := findConfig(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+			os.Exit(1)
+		}
+
+		if filename != "" {
+			if err := config.Value.Set(filename); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	if testsOnly := flag.Lookup("tests-only"); testsOnly.Value.String() == "true" && !*tests {
+		fmt.Fprintf(os.Stderr, "durationcheck: -tests-only requires -test\n")
+		os.Exit(2)
+	}
+
+	write, ok := writers[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "durationcheck: unknown format %q\n", *format)
+		os.Exit(2)
+	}
+
+	switch {
+	case *groupBy == "owner" && *format != "text":
+		fmt.Fprintf(os.Stderr, "durationcheck: -group-by requires -format=text\n")
+		os.Exit(2)
+	case *groupBy == "owner":
+		write = writeByOwner
+	case *groupBy != "":
+		fmt.Fprintf(os.Stderr, "durationcheck: unknown -group-by %q\n", *groupBy)
+		os.Exit(2)
+	}
+
+	if !validFailOn(*failOn) {
+		fmt.Fprintf(os.Stderr, "durationcheck: unknown -fail-on %q\n", *failOn)
+		os.Exit(2)
+	}
+
+	if *maxIssues < 0 {
+		fmt.Fprintf(os.Stderr, "durationcheck: -max-issues must not be negative\n")
+		os.Exit(2)
+	}
+
+	if *workers < 1 {
+		fmt.Fprintf(os.Stderr, "durationcheck: -j must be at least 1\n")
+		os.Exit(2)
+	}
+
+	// go/packages and the analysis driver spread their work over GOMAXPROCS threads
+	runtime.GOMAXPROCS(*workers)
+
+	if *train {
+		analyzer = trainingAnalyzer
+	}
+
+	findings, err := run(flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *train {
+		if err := trainer.WriteConfig(os.Stdout, trainMinCount); err != nil {
+			fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *baselineFile != "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+			os.Exit(1)
+		}
+
+		if *baselineFile == generateBaseline {
+			if err := writeBaseline(defaultBaselineFile, findings, repoRoot(wd)); err != nil {
+				fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "durationcheck: recorded %d findings in %s\n", len(findings), defaultBaselineFile)
+			return
+		}
+
+		entries, err := loadBaseline(*baselineFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+			os.Exit(1)
+		}
+
+		counts := baselineCounts(entries)
+		findings = applyBaseline(findings, counts)
+		if *staleBaseline {
+			writeStaleEntries(os.Stderr, *baselineFile, staleEntries(entries, counts))
+		}
+	}
+
+	shown := findings
+	if *maxIssues > 0 && len(shown) > *maxIssues {
+		shown = shown[:*maxIssues]
+	}
+
+	if err := write(os.Stdout, shown); err != nil {
+		fmt.Fprintf(os.Stderr, "durationcheck: %v\n", err)
+		os.Exit(1)
+	}
+
+	if hidden := len(findings) - len(shown); hidden > 0 {
+		fmt.Fprintf(os.Stderr, "durationcheck: %d more findings not shown, see -max-issues\n", hidden)
+	}
+
+	if failing(findings, *failOn) {
+		os.Exit(3)
+	}
+}
+
+func run(patterns []string) ([]finding, error) {
+	if *stdin {
+		pkgs, filename, err := loadStdin(*stdinName, os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+
+		// the buffer being edited often doesn't compile, the analyzer makes the most of the available types
+		analyzer.RunDespiteErrors = true
+
+		return analyze(pkgs, filename)
+	}
+
+	pkgs, err := load(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("failed to load packages")
+	}
+
+	return analyze(pkgs, "")
+}
+
+func load(patterns []string) ([]*packages.Package, error) {
+	if *goList != "" {
+		return loadGoList(*goList, *workers)
+	}
+
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Tests: *tests}
+	return packages.Load(cfg, patterns...)
+}
+
+// analyze runs the analyzer over the packages, and returns the findings of the given file only if not empty
+func analyze(pkgs []*packages.Package, only string) ([]finding, error) {
+	opts := &checker.Options{Sequential: *workers == 1}
+	graph, err := checker.Analyze([]*analysis.Analyzer{throttle(analyzer, *workers)}, pkgs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var trimmer *pathTrimmer
+	if *trimPath {
+		trimmer, err = newPathTrimmer()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	co, err := loadOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := parseSeverities(*severities)
+	if err != nil {
+		return nil, err
+	}
+
+	var suppressed map[string]bool
+	if *suppressFile != "" {
+		if suppressed, err = loadSuppressions(*suppressFile); err != nil {
+			return nil, err
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	root := repoRoot(wd)
+
+	var findings []finding
+	counts := map[*packages.Package]int{}
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err)
+		}
+
+		for _, diag := range act.Diagnostics {
+			posn := act.Package.Fset.Position(diag.Pos)
+			if only != "" && posn.Filename != only {
+				continue
+			}
+
+			f := finding{
+				Rule:       diag.Category,
+				Filename:   trimmer.trim(posn.Filename),
+				Line:       posn.Line,
+				Column:     posn.Column,
+				Message:    diag.Message,
+				Expression: expressionAt(act.Package, diag.Pos, diag.End),
+				Severity:   severityOf(config, overrides, diag.Category, posn.Filename),
+				Confidence: durationcheck.RuleConfidence(diag.Category),
+				Function:   enclosingFunc(act.Package, diag.Pos),
+				Owners:     co.owners(posn.Filename),
+				URL:        diag.URL,
+			}
+			if diag.End.IsValid() {
+				end := act.Package.Fset.Position(diag.End)
+				f.EndLine, f.EndColumn = end.Line, end.Column
+			}
+			f.Fingerprint = fingerprint(f, repoPath(root, posn.Filename))
+
+			findings = append(findings, f)
+			counts[act.Package]++
+		}
+	}
+
+	if *stats {
+		if err := writeStats(os.Stderr, collectStats(graph, counts)); err != nil {
+			return nil, err
+		}
+	}
+
+	// test variants of a package report the findings of its non-test files again
+	findings = sortFindings(findings)
+
+	return suppress(findings, suppressed), nil
+}
+
+// loadOwners loads the CODEOWNERS file set with -codeowners, or found in the working directory
+func loadOwners() (*codeowners, error) {
+	if *owners != "" {
+		return loadCodeowners(*owners)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return findCodeowners(wd)
+}
+
+// loadConfig loads the configuration file of the -config flag, if any, for the severities of the findings
+func loadConfig() (*durationcheck.Config, error) {
+	filename := analyzer.Flags.Lookup("config").Value.String()
+	if filename == "" {
+		return nil, nil
+	}
+
+	return durationcheck.LoadConfig(filename)
+}
+
+// teeValue sets the flags of durationcheck.Analyzer and trainingAnalyzer together
+type teeValue [2]flag.Value
+
+func (v teeValue) String() string {
+	if v[0] == nil {
+		return ""
+	}
+
+	return v[0].String()
+}
+
+func (v teeValue) Set(s string) error {
+	for _, value := range v {
+		if err := value.Set(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsBoolFlag lets boolean flags be set without a value, e.g. -strict
+func (v teeValue) IsBoolFlag() bool {
+	b, ok := v[0].(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
 }
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestTeeValue(t *testing.T) {
+	first, second := flag.NewFlagSet("first", flag.ContinueOnError), flag.NewFlagSet("second", flag.ContinueOnError)
+	first.Bool("strict", false, "")
+	second.Bool("strict", false, "")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(teeValue{first.Lookup("strict").Value, second.Lookup("strict").Value}, "strict", "")
+
+	if err := fs.Parse([]string{"-strict"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []*flag.FlagSet{first, second} {
+		if got := f.Lookup("strict").Value.String(); got != "true" {
+			t.Errorf("%s: strict = %s, want true", f.Name(), got)
+		}
+	}
+}
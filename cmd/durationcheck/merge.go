@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// runMerge implements `durationcheck merge [-o output] reports...`, which merges JSON reports into a single sorted
+// report without duplicates
+func runMerge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	output := fs.String("o", "", "write the merged report to this file instead of the standard output")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: durationcheck merge [-o output] reports...\n")
+		fs.PrintDefaults()
+	}
+
+	filenames, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(filenames) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var findings []finding
+	for _, filename := range filenames {
+		r, err := readReport(filename)
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, r.Findings...)
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		return writeJSON(w, sortFindings(findings))
+	})
+}
+
+// parseInterleaved parses flags that may appear after positional arguments and returns the positional arguments
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// writeOutput writes to the file, or to the standard output if filename is empty
+func writeOutput(filename string, write func(io.Writer) error) error {
+	if filename == "" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
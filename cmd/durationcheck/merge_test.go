@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRunMerge(t *testing.T) {
+	dir := t.TempDir()
+
+	a := finding{Rule: "mul", Filename: "a/a.go", Line: 3, Column: 6, Message: "Multiplication of durations: `d * time.Second`"}
+	b := finding{Rule: "mul", Filename: "b/b.go", Line: 10, Column: 2, Message: "Multiplication of durations: `x * y`"}
+	c := finding{Rule: "bitwise", Filename: "a/a.go", Line: 3, Column: 6, Message: "Bitwise operation on durations: `d & 1`"}
+	for _, f := range []*finding{&a, &b, &c} {
+		f.Fingerprint = fingerprint(*f, f.Filename)
+	}
+
+	writeReport(t, filepath.Join(dir, "one.json"), []finding{b, a})
+	writeReport(t, filepath.Join(dir, "two.json"), []finding{a, c})
+
+	output := filepath.Join(dir, "merged.json")
+	if err := runMerge([]string{filepath.Join(dir, "one.json"), "-o", output, filepath.Join(dir, "two.json")}); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := readReport(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []finding{c, a, b}; !reflect.DeepEqual(merged.Findings, want) {
+		t.Errorf("got %v, want %v", merged.Findings, want)
+	}
+}
+
+func writeReport(t *testing.T, filename string, findings []finding) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, findings); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filename, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// finding is a diagnostic resolved to its position
+type finding struct {
+	Rule     string `json:"rule"`
+	Filename string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	// EndLine and EndColumn are the end of the reported expression, if known
+	EndLine   int    `json:"end_line,omitempty"`
+	EndColumn int    `json:"end_column,omitempty"`
+	Message   string `json:"message"`
+	// Expression is the reported expression or statement formatted with gofmt, untruncated unlike in the message
+	Expression string `json:"expression,omitempty"`
+	// Severity is the severity of the rule in the file set by the configuration, error by default
+	Severity string `json:"severity,omitempty"`
+	// Confidence is the confidence of the rule, certain or probable
+	Confidence string `json:"confidence,omitempty"`
+	// Function is the name of the function declaration enclosing the finding, if any
+	Function string `json:"function,omitempty"`
+	// Fingerprint identifies the finding independently of its line, see fingerprint
+	Fingerprint string `json:"fingerprint"`
+	// Owners are the code owners of the file, see codeowners
+	Owners []string `json:"owners,omitempty"`
+	// URL is the documentation of the rule
+	URL string `json:"url,omitempty"`
+}
+
+func (f finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", f.Filename, f.Line, f.Column, f.Message)
+}
+
+// report is the content of a JSON report
+type report struct {
+	Findings []finding `json:"findings"`
+}
+
+// fingerprint hashes the rule, the path of the file in the repository, the enclosing function and the expression of
+// the finding, so that it survives unrelated changes shifting lines, -trimpath and checkouts in other directories. The
+// message isn't hashed: it is rendered for readers, truncated and possibly translated.
+func fingerprint(f finding, path string) string {
+	h := sha256.New()
+	for _, s := range []string{f.Rule, path, f.Function, f.Expression} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// repoRoot returns the root of the repository containing dir, the first directory up holding `.git`, or dir itself
+// outside of a repository
+func repoRoot(dir string) string {
+	for root := dir; ; {
+		if _, err := os.Stat(filepath.Join(root, ".git")); err == nil {
+			return root
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return dir
+		}
+		root = parent
+	}
+}
+
+// repoPath returns the path of the file relative to the root using forward slashes, the file as is outside of it
+func repoPath(root, filename string) string {
+	if rel, ok := cutDir(filename, root); ok {
+		return filepath.ToSlash(rel)
+	}
+
+	return filepath.ToSlash(filename)
+}
+
+// enclosingFunc returns the name of the function declaration containing pos, e.g. `Client.Do` for methods
+func enclosingFunc(pkg *packages.Package, pos token.Pos) string {
+	for _, file := range pkg.Syntax {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || pos < fn.Pos() || pos > fn.End() {
+				continue
+			}
+
+			if recv := recvName(fn); recv != "" {
+				return recv + "." + fn.Name.Name
+			}
+			return fn.Name.Name
+		}
+	}
+
+	return ""
+}
+
+// gofmtConfig prints nodes like gofmt
+var gofmtConfig = &printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+
+// expressionAt returns the node of the package spanning from pos to end formatted with gofmt, e.g. the multiplication
+// reported by a finding, the comment starting at pos for directives, or "" if there is none
+func expressionAt(pkg *packages.Package, pos, end token.Pos) string {
+	for _, file := range pkg.Syntax {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if comment.Pos() == pos {
+					return comment.Text
+				}
+			}
+		}
+
+		path, _ := astutil.PathEnclosingInterval(file, pos, end)
+		if len(path) == 0 || path[0].Pos() != pos || path[0].End() != end {
+			return ""
+		}
+
+		var buf bytes.Buffer
+		if err := gofmtConfig.Fprint(&buf, token.NewFileSet(), path[0]); err != nil {
+			return ""
+		}
+		return buf.String()
+	}
+
+	return ""
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// sortFindings sorts the findings by position, then rule and message, and removes duplicates
+func sortFindings(findings []finding) []finding {
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Message < b.Message
+	})
+
+	var deduped []finding
+	for i, f := range findings {
+		if i == 0 || !sameFinding(f, findings[i-1]) {
+			deduped = append(deduped, f)
+		}
+	}
+
+	return deduped
+}
+
+// sameFinding compares the findings, except for their owners which are derived from their file
+func sameFinding(a, b finding) bool {
+	return a.Rule == b.Rule && a.Filename == b.Filename && a.Line == b.Line && a.Column == b.Column &&
+		a.Message == b.Message && a.Expression == b.Expression && a.Function == b.Function && a.Fingerprint == b.Fingerprint
+}
+
+// writers write the findings in each output format
+var writers = map[string]func(io.Writer, []finding) error{
+	"text":  writeText,
+	"json":  writeJSON,
+	"csv":   writeCSV,
+	"sql":   writeSQL,
+	"sarif": writeSARIF,
+}
+
+func writeText(w io.Writer, findings []finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintln(w, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, findings []finding) error {
+	if findings == nil {
+		findings = []finding{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report{Findings: findings})
+}
+
+func readReport(filename string) (*report, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &report{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parsing report %s: %w", filename, err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/packages"
+)
+
+// analyzeModule writes the files of a module named example.com/m in a temporary directory and analyzes its packages
+func analyzeModule(t *testing.T, dir string, files map[string]string) []finding {
+	t.Helper()
+
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/m\n\ngo 1.22\n")
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, name), content)
+	}
+
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("failed to load packages")
+	}
+
+	findings, err := analyze(pkgs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return findings
+}
+
+func TestFindingExpression(t *testing.T) {
+	maxExprLen := durationcheck.Analyzer.Flags.Lookup("max-expr-len")
+	old := maxExprLen.Value.String()
+	if err := maxExprLen.Value.Set("20"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = maxExprLen.Value.Set(old) })
+
+	findings := analyzeModule(t, t.TempDir(), map[string]string{"m.go": `package m
+
+import "time"
+
+func f(firstTimeout, secondTimeout time.Duration) {
+	_ = firstTimeout * secondTimeout * time.Second
+	_ = firstTimeout * secondTimeout * time.Minute
+}
+`})
+
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2: %v", len(findings), findings)
+	}
+
+	for i, want := range []string{"firstTimeout * secondTimeout * time.Second", "firstTimeout * secondTimeout * time.Minute"} {
+		if findings[i].Expression != want {
+			t.Errorf("finding %d: got expression %q, want %q", i, findings[i].Expression, want)
+		}
+	}
+
+	if findings[0].Message[:60] != findings[1].Message[:60] {
+		t.Errorf("expected messages truncated alike, got %q and %q", findings[0].Message, findings[1].Message)
+	}
+
+	if findings[0].Fingerprint == findings[1].Fingerprint {
+		t.Errorf("the findings share the fingerprint %s", findings[0].Fingerprint)
+	}
+}
+
+// TestFingerprintPath checks that the fingerprints of findings hash their path in the repository, whatever -trimpath,
+// the working directory in the repository or where it is checked out
+func TestFingerprintPath(t *testing.T) {
+	files := map[string]string{"m.go": `package m
+
+import "time"
+
+func f(timeout time.Duration) time.Duration {
+	return timeout * time.Second
+}
+`}
+
+	old := *trimPath
+	t.Cleanup(func() { *trimPath = old })
+
+	var fingerprints []string
+	for _, trim := range []bool{false, true} {
+		for _, sub := range []string{"", "sub"} {
+			dir := t.TempDir()
+			for _, name := range []string{".git", "sub"} {
+				if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			t.Chdir(filepath.Join(dir, sub))
+			*trimPath = trim
+
+			findings := analyzeModule(t, dir, files)
+			if len(findings) != 1 {
+				t.Fatalf("got %d findings, want 1: %v", len(findings), findings)
+			}
+			fingerprints = append(fingerprints, findings[0].Fingerprint)
+		}
+	}
+
+	for _, fp := range fingerprints[1:] {
+		if fp != fingerprints[0] {
+			t.Fatalf("got different fingerprints %v", fingerprints)
+		}
+	}
+}
+
+// TestFingerprintTranslated checks that the fingerprints of findings don't change with the message catalog, directives
+// included, whose source quotes no expression
+func TestFingerprintTranslated(t *testing.T) {
+	config := durationcheck.Analyzer.Flags.Lookup("config")
+	old := config.Value.String()
+	t.Cleanup(func() { _ = config.Value.Set(old) })
+
+	files := map[string]string{"m.go": `package m
+
+import "time"
+
+func f(timeout time.Duration) time.Duration {
+	//durationcheck:ignore first
+	//durationcheck:ignore second
+	return timeout * time.Second
+}
+`}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "durationcheck.yml"), "messages:\n  catalog: messages.ja.yml\n")
+	writeFile(t, filepath.Join(dir, "messages.ja.yml"), `"Multiplication of durations: `+"`%s`"+`": "期間同士の乗算: `+"`%s`"+`"
+"Invalid durationcheck:ignore directive: %v": "無効なdurationcheck:ignoreディレクティブ: %v"
+`)
+
+	var fingerprints, messages [][]string
+	for _, filename := range []string{"", filepath.Join(dir, "durationcheck.yml")} {
+		if err := config.Value.Set(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		var fps, msgs []string
+		for _, f := range analyzeModule(t, dir, files) {
+			fps = append(fps, f.Fingerprint)
+			msgs = append(msgs, f.Message)
+		}
+		fingerprints = append(fingerprints, fps)
+		messages = append(messages, msgs)
+	}
+
+	if len(fingerprints[0]) != 3 {
+		t.Fatalf("got %d findings, want 3", len(fingerprints[0]))
+	}
+	if reflect.DeepEqual(messages[0], messages[1]) {
+		t.Fatalf("the catalog didn't translate the messages: %v", messages[1])
+	}
+	if fingerprints[0][0] == fingerprints[0][1] {
+		t.Errorf("the directives share the fingerprint %s", fingerprints[0][0])
+	}
+	if !reflect.DeepEqual(fingerprints[0], fingerprints[1]) {
+		t.Errorf("the fingerprints changed with the messages: %v and %v", fingerprints[0], fingerprints[1])
+	}
+}
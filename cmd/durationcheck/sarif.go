@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/charithe/durationcheck"
+)
+
+// sarifVersion is the version of the SARIF format written by writeSARIF, the one GitHub code scanning accepts
+const sarifVersion = "2.1.0"
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifLevels maps the severities of the configuration to SARIF levels
+var sarifLevels = map[string]string{
+	durationcheck.SeverityError:   "error",
+	durationcheck.SeverityWarning: "warning",
+	durationcheck.SeverityInfo:    "note",
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          *sarifProperties  `json:"properties,omitempty"`
+}
+
+// sarifProperties are the properties of a result specific to durationcheck
+type sarifProperties struct {
+	// Expression is the untruncated reported expression, see finding
+	Expression string `json:"expression"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// writeSARIF writes the findings as a SARIF log, e.g. for GitHub code scanning. The fingerprints of the findings let
+// code scanning track them across runs. Paths are relative with -trimpath, absolute file URIs otherwise.
+func writeSARIF(w io.Writer, findings []finding) error {
+	results := make([]sarifResult, 0, len(findings))
+	var ruleIDs []string
+	seen := map[string]bool{}
+
+	for _, f := range findings {
+		if !seen[f.Rule] {
+			seen[f.Rule] = true
+			ruleIDs = append(ruleIDs, f.Rule)
+		}
+
+		level, ok := sarifLevels[f.Severity]
+		if !ok {
+			level = "error"
+		}
+
+		var properties *sarifProperties
+		if f.Expression != "" {
+			properties = &sarifProperties{Expression: f.Expression}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  f.Rule,
+			Level:   level,
+			Message: sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(f.Filename)},
+				Region:           sarifRegion{StartLine: f.Line, StartColumn: f.Column, EndLine: f.EndLine, EndColumn: f.EndColumn},
+			}}},
+			PartialFingerprints: map[string]string{"durationcheck/v1": f.Fingerprint},
+			Properties:          properties,
+		})
+	}
+
+	sort.Strings(ruleIDs)
+	rules := make([]sarifRule, len(ruleIDs))
+	for i, id := range ruleIDs {
+		rules[i] = sarifRule{ID: id, HelpURI: durationcheck.RuleURL(id)}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "durationcheck",
+				InformationURI: "https://github.com/charithe/durationcheck",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifURI returns the URI of a file, relative for relative paths
+func sarifURI(filename string) string {
+	if filepath.IsAbs(filename) {
+		return "file://" + filepath.ToSlash(filename)
+	}
+
+	return filepath.ToSlash(filename)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charithe/durationcheck"
+)
+
+// failNone is the value of -fail-on never failing on findings
+const failNone = "none"
+
+// severityRanks orders the severities, the most severe first
+var severityRanks = map[string]int{
+	durationcheck.SeverityError:   0,
+	durationcheck.SeverityWarning: 1,
+	durationcheck.SeverityInfo:    2,
+}
+
+// parseSeverities parses the -severity entries, e.g. `names=warning,bitwise=info`, into the severity of each rule
+func parseSeverities(value string) (map[string]string, error) {
+	severities := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		rule, severity, ok := strings.Cut(entry, "=")
+		if _, known := severityRanks[severity]; !ok || !known || rule == "" {
+			return nil, fmt.Errorf("invalid -severity entry %q, expected rule=severity with severity one of error, warning, info", entry)
+		}
+		severities[rule] = severity
+	}
+
+	return severities, nil
+}
+
+// severityOf returns the severity of a finding of the rule in the file: the one set by -severity, or else by the
+// configuration
+func severityOf(config *durationcheck.Config, severities map[string]string, rule, filename string) string {
+	if severity, ok := severities[rule]; ok {
+		return severity
+	}
+
+	return config.Severity(rule, filename)
+}
+
+// validFailOn returns true if the -fail-on value is a severity or none
+func validFailOn(failOn string) bool {
+	_, ok := severityRanks[failOn]
+	return ok || failOn == failNone
+}
+
+// failing returns true if a finding is at least as severe as -fail-on
+func failing(findings []finding, failOn string) bool {
+	threshold, ok := severityRanks[failOn]
+	if !ok {
+		return false
+	}
+
+	for _, f := range findings {
+		rank, ok := severityRanks[f.Severity]
+		if !ok {
+			rank = severityRanks[durationcheck.SeverityError]
+		}
+
+		if rank <= threshold {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSeverities(t *testing.T) {
+	severities, err := parseSeverities("names=warning, bitwise=info,,mul=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"names": "warning", "bitwise": "info", "mul": "error"}
+	if !reflect.DeepEqual(severities, want) {
+		t.Errorf("got %v, want %v", severities, want)
+	}
+
+	for _, value := range []string{"names", "names=fatal", "=warning"} {
+		if _, err := parseSeverities(value); err == nil {
+			t.Errorf("parseSeverities(%q): expected an error", value)
+		}
+	}
+}
+
+func TestSeverityOf(t *testing.T) {
+	severities := map[string]string{"names": "info"}
+
+	if got := severityOf(nil, severities, "names", "a.go"); got != "info" {
+		t.Errorf("severity of names = %q, want info", got)
+	}
+
+	if got := severityOf(nil, severities, "mul", "a.go"); got != "error" {
+		t.Errorf("severity of mul = %q, want the default error", got)
+	}
+}
+
+func TestFailing(t *testing.T) {
+	findings := []finding{{Severity: "info"}, {Severity: "warning"}}
+
+	testCases := map[string]bool{
+		"error":   false,
+		"warning": true,
+		"info":    true,
+		"none":    false,
+	}
+
+	for failOn, want := range testCases {
+		if got := failing(findings, failOn); got != want {
+			t.Errorf("failing with -fail-on=%s = %t, want %t", failOn, got, want)
+		}
+	}
+
+	if !failing([]finding{{}}, "error") {
+		t.Error("a finding without severity should fail as an error")
+	}
+}
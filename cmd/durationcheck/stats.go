@@ -0,0 +1,66 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// packageStats is the analysis time and the number of findings of a package, printed with -stats
+type packageStats struct {
+	ID       string
+	Duration time.Duration
+	Findings int
+}
+
+// collectStats returns the statistics of the root packages of the graph, slowest first. The time of a package sums
+// all the analyzers run on it, including the required ones exporting facts, and its findings are counted before the
+// test variants are deduplicated and the suppressions applied.
+func collectStats(graph *checker.Graph, findings map[*packages.Package]int) []packageStats {
+	durations := map[*packages.Package]time.Duration{}
+	for act := range graph.All() {
+		durations[act.Package] += act.Duration
+	}
+
+	var stats []packageStats
+	seen := map[*packages.Package]bool{}
+	for _, act := range graph.Roots {
+		if seen[act.Package] {
+			continue
+		}
+		seen[act.Package] = true
+
+		stats = append(stats, packageStats{ID: act.Package.ID, Duration: durations[act.Package], Findings: findings[act.Package]})
+	}
+
+	slices.SortFunc(stats, func(a, b packageStats) int {
+		if a.Duration != b.Duration {
+			return cmp.Compare(b.Duration, a.Duration)
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return stats
+}
+
+// writeStats writes the statistics as a table followed by the totals
+func writeStats(w io.Writer, stats []packageStats) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	var total time.Duration
+	var findings int
+	for _, s := range stats {
+		total += s.Duration
+		findings += s.Findings
+		fmt.Fprintf(tw, "%s\t%s\t%d findings\n", s.ID, s.Duration.Round(time.Microsecond), s.Findings)
+	}
+	fmt.Fprintf(tw, "total (%d packages)\t%s\t%d findings\n", len(stats), total.Round(time.Microsecond), findings)
+
+	return tw.Flush()
+}
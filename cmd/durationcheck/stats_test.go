@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCollectStats(t *testing.T) {
+	a := &packages.Package{ID: "example.com/a"}
+	b := &packages.Package{ID: "example.com/b"}
+	dep := &packages.Package{ID: "example.com/dep"}
+	facts := &analysis.Analyzer{Name: "facts"}
+
+	graph := &checker.Graph{Roots: []*checker.Action{
+		{Analyzer: durationcheck.Analyzer, Package: a, IsRoot: true, Duration: 2 * time.Millisecond, Deps: []*checker.Action{
+			{Analyzer: facts, Package: a, Duration: time.Millisecond},
+			{Analyzer: facts, Package: dep, Duration: 5 * time.Millisecond},
+		}},
+		{Analyzer: durationcheck.Analyzer, Package: b, IsRoot: true, Duration: 4 * time.Millisecond},
+	}}
+
+	got := collectStats(graph, map[*packages.Package]int{a: 3})
+	want := []packageStats{
+		{ID: "example.com/b", Duration: 4 * time.Millisecond},
+		{ID: "example.com/a", Duration: 3 * time.Millisecond, Findings: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeStats(&buf, []packageStats{
+		{ID: "example.com/m/sub", Duration: 1500 * time.Microsecond, Findings: 2},
+		{ID: "example.com/m", Duration: 500 * time.Microsecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `example.com/m/sub   1.5ms  2 findings
+example.com/m       500µs  0 findings
+total (2 packages)  2ms    2 findings
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadStdin loads the packages of the file named by -stdin-filename, with the content read from r in place of the
+// file on disk, e.g. the unsaved buffer of an editor. It returns the absolute name of the file. The packages may not
+// type check: the analysis runs on whatever type information they have.
+func loadStdin(filename string, r io.Reader) ([]*packages.Package, string, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading stdin: %w", err)
+	}
+
+	cfg := &packages.Config{
+		Mode:    packages.LoadAllSyntax,
+		Tests:   *tests,
+		Dir:     filepath.Dir(abs),
+		Overlay: map[string][]byte{abs: content},
+	}
+
+	pkgs, err := packages.Load(cfg, "file="+abs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var found []*packages.Package
+	for _, pkg := range pkgs {
+		for _, f := range pkg.CompiledGoFiles {
+			if f == abs {
+				found = append(found, pkg)
+				break
+			}
+		}
+	}
+
+	if len(found) == 0 {
+		packages.PrintErrors(pkgs)
+		return nil, "", fmt.Errorf("no package contains %s", filename)
+	}
+
+	return found, abs, nil
+}
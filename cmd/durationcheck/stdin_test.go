@@ -0,0 +1,76 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charithe/durationcheck"
+)
+
+func TestLoadStdin(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/m\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(dir, "m.go"), `package m
+
+import "time"
+
+const Timeout = 10 * time.Second
+`)
+	writeFile(t, filepath.Join(dir, "f.go"), `package m
+
+func f() {}
+`)
+	writeFile(t, filepath.Join(dir, "g.go"), `package m
+
+import "time"
+
+func g(d time.Duration) time.Duration { return d * Timeout }
+`)
+
+	// the unsaved buffer of f.go, which doesn't compile yet
+	buffer := `package m
+
+import "time"
+
+func f(d time.Duration) time.Duration {
+	undefined()
+	return d * Timeout
+}
+
+func delay(n int) time.Duration {
+	return time.Duration(n+undefinedJitter) * time.Millisecond
+}
+`
+
+	pkgs, filename, err := loadStdin(filepath.Join(dir, "f.go"), strings.NewReader(buffer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filename != filepath.Join(dir, "f.go") {
+		t.Errorf("got file %s, want %s", filename, filepath.Join(dir, "f.go"))
+	}
+
+	durationcheck.Analyzer.RunDespiteErrors = true
+	t.Cleanup(func() { durationcheck.Analyzer.RunDespiteErrors = false })
+
+	findings, err := analyze(pkgs, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the finding of g.go isn't reported, only those of the buffer
+	if len(findings) != 1 || findings[0].Filename != filename || findings[0].Line != 7 {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func TestLoadStdinNoPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/m\n\ngo 1.22\n")
+
+	if _, _, err := loadStdin(filepath.Join(dir, "sub", "f.go"), strings.NewReader("package sub\n")); err == nil {
+		t.Error("expected an error for a file outside of any package")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// loadSuppressions reads a list of fingerprints to suppress, one per line. Blank lines and comments starting with
+// `#` are ignored, including after a fingerprint, so that entries can tell why they are suppressed.
+func loadSuppressions(filename string) (map[string]bool, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	suppressed := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+
+		fields := strings.Fields(text)
+		switch len(fields) {
+		case 0:
+			continue
+		case 1:
+			suppressed[fields[0]] = true
+		default:
+			return nil, fmt.Errorf("%s:%d: expected a single fingerprint, got %q", filename, line, strings.TrimSpace(text))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return suppressed, nil
+}
+
+// suppress removes the findings whose fingerprints are suppressed
+func suppress(findings []finding, suppressed map[string]bool) []finding {
+	var kept []finding
+	for _, f := range findings {
+		if !suppressed[f.Fingerprint] {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept
+}
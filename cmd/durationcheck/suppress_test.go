@@ -0,0 +1,41 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSuppress(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "suppressions.txt")
+	writeFile(t, filename, `# legacy timeouts, see #123
+0123456789abcdef
+  fedcba9876543210   # reviewed
+
+`)
+
+	suppressed, err := loadSuppressions(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := []finding{
+		{Filename: "a.go", Line: 1, Message: "first", Fingerprint: "0123456789abcdef"},
+		{Filename: "b.go", Line: 2, Message: "second", Fingerprint: "00000000000000ff"},
+		{Filename: "c.go", Line: 3, Message: "third", Fingerprint: "fedcba9876543210"},
+	}
+
+	want := []finding{findings[1]}
+	if got := suppress(findings, suppressed); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLoadSuppressionsInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "suppressions.txt")
+	writeFile(t, filename, "0123456789abcdef\na.go:1:1: message\n")
+
+	if _, err := loadSuppressions(filename); err == nil {
+		t.Error("expected an error for a line that isn't a fingerprint")
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import "golang.org/x/tools/go/analysis"
+
+// throttle returns a copy of the analyzer running on at most n packages at a time, for -j. The analysis driver starts
+// the analysis of every package as soon as its dependencies are analyzed, whatever the number of threads.
+func throttle(a *analysis.Analyzer, n int) *analysis.Analyzer {
+	sem := make(chan struct{}, max(n, 1))
+	run := a.Run
+
+	throttled := *a
+	throttled.Run = func(pass *analysis.Pass) (interface{}, error) {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+
+		return run(pass)
+	}
+
+	return &throttled
+}
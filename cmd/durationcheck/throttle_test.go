@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestThrottle(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	a := &analysis.Analyzer{
+		Name: "slow",
+		Doc:  "slow",
+		Run: func(*analysis.Pass) (interface{}, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil, nil
+		},
+	}
+
+	throttled := throttle(a, 2)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = throttled.Run(nil)
+		}()
+	}
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("got %d concurrent runs, want 2", peak)
+	}
+
+	if a.Run == nil || throttled == a {
+		t.Error("the analyzer was modified instead of copied")
+	}
+}
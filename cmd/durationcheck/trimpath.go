@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// pathTrimmer strips machine specific prefixes from file paths so that reports are identical across machines
+type pathTrimmer struct {
+	// prefixes are tried in order, the most specific first
+	prefixes []string
+}
+
+func newPathTrimmer() (*pathTrimmer, error) {
+	out, err := exec.Command("go", "env", "-json", "GOMODCACHE", "GOPATH", "GOROOT").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var env struct {
+		GOMODCACHE string
+		GOPATH     string
+		GOROOT     string
+	}
+	if err := json.Unmarshal(out, &env); err != nil {
+		return nil, err
+	}
+
+	var prefixes []string
+
+	if wd, err := os.Getwd(); err == nil {
+		prefixes = append(prefixes, wd)
+	}
+
+	prefixes = append(prefixes, env.GOMODCACHE)
+	for _, dir := range filepath.SplitList(env.GOPATH) {
+		prefixes = append(prefixes, filepath.Join(dir, "src"))
+	}
+	prefixes = append(prefixes, filepath.Join(env.GOROOT, "src"))
+
+	if home, err := os.UserHomeDir(); err == nil {
+		prefixes = append(prefixes, home)
+	}
+
+	return &pathTrimmer{prefixes: prefixes}, nil
+}
+
+// trim returns the path relative to the first matching prefix, using forward slashes
+func (t *pathTrimmer) trim(filename string) string {
+	if t == nil {
+		return filename
+	}
+
+	for _, prefix := range t.prefixes {
+		if prefix == "" {
+			continue
+		}
+
+		if rel, ok := cutDir(filename, prefix); ok {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return filepath.ToSlash(filename)
+}
+
+// cutDir returns the path of filename relative to dir if filename is inside dir
+func cutDir(filename, dir string) (string, bool) {
+	rel, ok := strings.CutPrefix(filename, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+	return rel, ok && rel != ""
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestPathTrimmer(t *testing.T) {
+	trimmer := &pathTrimmer{prefixes: []string{
+		"/home/gopher/src/project",
+		"/home/gopher/go/pkg/mod",
+		"/home/gopher/go/src",
+		"/usr/local/go/src",
+		"/home/gopher",
+	}}
+
+	testCases := map[string]string{
+		"/home/gopher/src/project/a/a.go":                   "a/a.go",
+		"/home/gopher/src/projects/a/a.go":                  "src/projects/a/a.go",
+		"/home/gopher/go/pkg/mod/example.com/m@v1.0.0/m.go": "example.com/m@v1.0.0/m.go",
+		"/home/gopher/go/src/example.com/m/m.go":            "example.com/m/m.go",
+		"/usr/local/go/src/time/time.go":                    "time/time.go",
+		"/tmp/a.go":                                         "/tmp/a.go",
+	}
+
+	for filename, want := range testCases {
+		if got := trimmer.trim(filename); got != want {
+			t.Errorf("trim(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
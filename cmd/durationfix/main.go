@@ -0,0 +1,160 @@
+// Command durationfix rewrites duration expressions into their canonical forms without reporting any findings.
+//
+// Usage:
+//
+//	durationfix [-l] [-tests] packages...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"sort"
+
+	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	list  = flag.Bool("l", false, "list the files that would be rewritten instead of rewriting them")
+	tests = flag.Bool("tests", true, "also rewrite test files")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: durationfix [flags] packages...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "durationfix: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(patterns []string) error {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Tests: *tests}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("failed to load packages")
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{durationcheck.FixAnalyzer}, pkgs, nil)
+	if err != nil {
+		return err
+	}
+
+	edits := make(map[string][]edit)
+	seen := make(map[edit]bool)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return act.Err
+		}
+
+		for _, diag := range act.Diagnostics {
+			for _, fix := range diag.SuggestedFixes {
+				for _, te := range fix.TextEdits {
+					e := newEdit(act.Package.Fset, te)
+					// test variants of a package report the same fixes again
+					if !seen[e] {
+						seen[e] = true
+						edits[e.filename] = append(edits[e.filename], e)
+					}
+				}
+			}
+		}
+	}
+
+	filenames := make([]string, 0, len(edits))
+	for filename := range edits {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		if *list {
+			fmt.Println(filename)
+			continue
+		}
+
+		if err := rewrite(filename, edits[filename]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// edit is a text replacement of the byte range [start, end) of a file
+type edit struct {
+	filename   string
+	start, end int
+	text       string
+}
+
+func newEdit(fset *token.FileSet, te analysis.TextEdit) edit {
+	file := fset.File(te.Pos)
+	return edit{
+		filename: file.Name(),
+		start:    file.Offset(te.Pos),
+		end:      file.Offset(te.End),
+		text:     string(te.NewText),
+	}
+}
+
+// rewrite applies the non-overlapping edits to the file and formats the result
+func rewrite(filename string, edits []edit) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start < edits[j].start
+		}
+		// prefer the outermost edit when several start at the same offset
+		return edits[i].end > edits[j].end
+	})
+
+	var buf bytes.Buffer
+	offset := 0
+	for _, e := range edits {
+		// an edit nested in an already applied one is dropped; it will be suggested again on the next run
+		if e.start < offset {
+			continue
+		}
+
+		buf.Write(src[offset:e.start])
+		buf.WriteString(e.text)
+		offset = e.end
+	}
+	buf.Write(src[offset:])
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: formatting rewritten source: %w", filename, err)
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, out, info.Mode())
+}
@@ -0,0 +1,34 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+)
+
+// checkLiteralComparison reports durations compared to bare numbers above the -unscaled-threshold, e.g.
+// `elapsed > 1000` which compares to 1000 nanoseconds, and suggests scaling the number by the common units
+func (c *checker) checkLiteralComparison(expr *ast.BinaryExpr) {
+	switch expr.Op {
+	case token.GTR, token.LSS, token.GEQ, token.LEQ, token.EQL, token.NEQ:
+	default:
+		return
+	}
+
+	d, lit := expr.X, expr.Y
+	if isNumberLiteral(d) {
+		d, lit = lit, d
+	}
+
+	if !isNumberLiteral(lit) || c.pass.TypesInfo.Types[d].Value != nil || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(d)) {
+		return
+	}
+
+	n, exact := constant.Int64Val(constant.ToInt(c.pass.TypesInfo.Types[lit].Value))
+	if !exact || n <= int64(c.settings.unscaledThreshold) && n >= -int64(c.settings.unscaledThreshold) {
+		return
+	}
+
+	c.reportFixf(RuleCompareLiteral, lit, c.scaleFixes(lit), "Duration `%s` compared to bare number `%s`, which is %d nanoseconds: multiply it by a unit",
+		c.formatExpr(d), c.formatExpr(lit), n)
+}
@@ -0,0 +1,429 @@
+package durationcheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/charithe/durationcheck/internal/pathmatch"
+	"gopkg.in/yaml.v3"
+)
+
+// Severities of the findings.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Config is the content of a durationcheck configuration file, e.g.
+//
+//	enable: ["names"]
+//	severity:
+//	  names: warning
+//	tests:
+//	  disable: ["names"]
+//	exemptions:
+//	  - paths: ["internal/clock/"]
+//	    rules: ["mul"]
+//	directives:
+//	  require-reason: true
+//	  report-unused: true
+//	generated:
+//	  skip: true
+//	  files: ["*_mock.go"]
+//	  headers: ["^// Autogenerated by"]
+//	messages:
+//	  catalog: messages.ja.yml
+//	ignore:
+//	  functions: ["example.com/clock.Jitter"]
+//	  identifiers: ["backoff"]
+//	  expressions: ["^retries \\* time\\.Second$"]
+//	exclude: ["third_party/"]
+//	duration-types: ["github.com/prometheus/common/model.Duration"]
+//	accept-names: ["factor", "retries"]
+type Config struct {
+	RuleSet `yaml:",inline"`
+
+	// Tests overrides the rule set for _test.go files.
+	Tests *RuleSet `yaml:"tests"`
+
+	// Exemptions disable rules for the files matching path patterns.
+	Exemptions []Exemption `yaml:"exemptions"`
+
+	// Directives controls the `//durationcheck:ignore` directives.
+	Directives Directives `yaml:"directives"`
+
+	// Generated controls the detection and skipping of generated files.
+	Generated Generated `yaml:"generated"`
+
+	// Messages translates the diagnostic messages.
+	Messages Messages `yaml:"messages"`
+
+	// Ignore accepts the findings involving safe functions or identifiers, or matching expression patterns.
+	Ignore Ignore `yaml:"ignore"`
+
+	// Exclude are the path patterns of the files that aren't analyzed, with the same syntax as exemption paths.
+	Exclude []string `yaml:"exclude"`
+	// DurationTypes are additional duration types, like -duration-types, which overrides them.
+	DurationTypes []string `yaml:"duration-types"`
+	// AcceptNames are the words naming the duration variables treated as counts, like -accept-names, which overrides
+	// them.
+	AcceptNames []string `yaml:"accept-names"`
+}
+
+// Ignore accepts idioms of a codebase, e.g. as suggested by the -train mode of the durationcheck command.
+type Ignore struct {
+	// Functions are the functions whose calls make a reported expression safe, identified by their package path
+	// followed by their name, or by their receiver type name and their name for methods.
+	Functions []string `yaml:"functions"`
+	// Identifiers are the names of the identifiers that make a reported expression safe.
+	Identifiers []string `yaml:"identifiers"`
+	// Expressions are regular expressions matched against the reported expressions, formatted with gofmt.
+	Expressions []string `yaml:"expressions"`
+
+	expressions []*regexp.Regexp
+}
+
+// Directives controls the `//durationcheck:ignore` directives.
+type Directives struct {
+	// RequireReason makes directives without a `reason=` invalid: they are reported and don't suppress anything.
+	RequireReason bool `yaml:"require-reason"`
+	// ReportUnused reports the directives that don't suppress any finding.
+	ReportUnused bool `yaml:"report-unused"`
+}
+
+// RuleSet enables or disables rules and sets their severities.
+type RuleSet struct {
+	Enable   []string          `yaml:"enable"`
+	Disable  []string          `yaml:"disable"`
+	Severity map[string]string `yaml:"severity"`
+}
+
+// Exemption disables a set of rules for the files matching any of its path patterns.
+//
+// Patterns are slash-separated globs where `**` matches any number of directories. A pattern ending with a slash
+// matches every file below that directory. Patterns that don't start with a slash may match at any directory level.
+type Exemption struct {
+	Paths []string `yaml:"paths"`
+	Rules []string `yaml:"rules"`
+}
+
+// Generated classifies files as generated. Files with the standard `// Code generated ... DO NOT EDIT.` header are
+// always generated; Files and Headers recognize the output of generators that don't emit it.
+type Generated struct {
+	// Skip disables every rule in generated files.
+	Skip bool `yaml:"skip"`
+	// Files are path patterns of generated files, with the same syntax as exemption paths.
+	Files []string `yaml:"files"`
+	// Headers are regular expressions matched against the comment lines preceding the package clause.
+	Headers []string `yaml:"headers"`
+
+	headers []*regexp.Regexp
+}
+
+// LoadConfig reads and validates a configuration file.
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	cfg := &Config{}
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing config %s: %w", filename, err)
+	}
+
+	if err := cfg.prepare(filepath.Dir(filename)); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", filename, err)
+	}
+
+	return cfg, nil
+}
+
+// prepare validates the configuration and loads its message catalog, relative to dir
+func (c *Config) prepare(dir string) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if catalog := c.Messages.Catalog; catalog != "" {
+		if !filepath.IsAbs(catalog) {
+			catalog = filepath.Join(dir, catalog)
+		}
+
+		var err error
+		if c.Messages.catalog, err = loadCatalog(catalog); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Severity returns the severity of the findings of the rule in the file. Analysis diagnostics don't carry a
+// severity, drivers apply it when reporting them.
+func (c *Config) Severity(rule, filename string) string {
+	if c == nil {
+		return SeverityError
+	}
+
+	if c.Tests != nil && isTestFile(filename) {
+		if severity, ok := c.Tests.Severity[rule]; ok {
+			return severity
+		}
+	}
+
+	if severity, ok := c.RuleSet.Severity[rule]; ok {
+		return severity
+	}
+
+	return SeverityError
+}
+
+func (c *Config) validate() error {
+	if err := c.RuleSet.validate(); err != nil {
+		return err
+	}
+
+	if c.Tests != nil {
+		if err := c.Tests.validate(); err != nil {
+			return fmt.Errorf("tests: %w", err)
+		}
+	}
+
+	for _, pattern := range c.Generated.Files {
+		if err := pathmatch.Validate(pattern); err != nil {
+			return err
+		}
+	}
+
+	for _, pattern := range c.Exclude {
+		if err := pathmatch.Validate(pattern); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range c.DurationTypes {
+		if err := validateDurationType(name); err != nil {
+			return err
+		}
+	}
+
+	for _, header := range c.Generated.Headers {
+		re, err := regexp.Compile(header)
+		if err != nil {
+			return fmt.Errorf("invalid generated header %q: %w", header, err)
+		}
+		c.Generated.headers = append(c.Generated.headers, re)
+	}
+
+	for _, expr := range c.Ignore.Expressions {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid ignored expression %q: %w", expr, err)
+		}
+		c.Ignore.expressions = append(c.Ignore.expressions, re)
+	}
+
+	for _, e := range c.Exemptions {
+		if err := validateRules(e.Rules); err != nil {
+			return err
+		}
+
+		for _, pattern := range e.Paths {
+			if err := pathmatch.Validate(pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *RuleSet) validate() error {
+	if err := validateRules(r.Enable); err != nil {
+		return err
+	}
+
+	if err := validateRules(r.Disable); err != nil {
+		return err
+	}
+
+	for rule, severity := range r.Severity {
+		if err := validateRules([]string{rule}); err != nil {
+			return err
+		}
+
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			return fmt.Errorf("unknown severity %q for rule %q", severity, rule)
+		}
+	}
+
+	return nil
+}
+
+// apply returns whether the rule is enabled after applying the rule set
+func (r *RuleSet) apply(rule string, enabled bool) bool {
+	if contains(r.Enable, rule) {
+		enabled = true
+	}
+
+	if contains(r.Disable, rule) {
+		enabled = false
+	}
+
+	return enabled
+}
+
+// enabled returns true if the rule is enabled for the file
+func (c *Config) enabled(rule, filename string, enabledByDefault bool) bool {
+	if c == nil {
+		return enabledByDefault
+	}
+
+	enabled := c.RuleSet.apply(rule, enabledByDefault)
+
+	if c.Tests != nil && isTestFile(filename) {
+		enabled = c.Tests.apply(rule, enabled)
+	}
+
+	return enabled && !c.exempted(rule, filename)
+}
+
+// excludedFile returns true if the file matches a pattern of Exclude
+func (c *Config) excludedFile(filename string) bool {
+	if c == nil {
+		return false
+	}
+
+	for _, pattern := range c.Exclude {
+		if pathmatch.Match(pattern, filename) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// durationTypes returns the additional duration types of the configuration unless the flag sets some
+func (c *Config) durationTypes(flag []string) []string {
+	if len(flag) > 0 || c == nil {
+		return flag
+	}
+
+	return c.DurationTypes
+}
+
+// acceptNames returns the words of the configuration naming counts unless the flag sets some
+func (c *Config) acceptNames(flag []string) []string {
+	if len(flag) > 0 || c == nil {
+		return flag
+	}
+
+	return c.AcceptNames
+}
+
+// mayEnable returns true if the rule is enabled for at least some files
+func (c *Config) mayEnable(rule string, enabledByDefault bool) bool {
+	if c == nil {
+		return enabledByDefault
+	}
+
+	return enabledByDefault || contains(c.Enable, rule) || (c.Tests != nil && contains(c.Tests.Enable, rule))
+}
+
+// exempted returns true if the rule is disabled for the file
+func (c *Config) exempted(rule, filename string) bool {
+	for _, e := range c.Exemptions {
+		if !contains(e.Rules, rule) {
+			continue
+		}
+
+		for _, pattern := range e.Paths {
+			if pathmatch.Match(pattern, filename) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// requireReason returns true if directives must give a reason
+func (c *Config) requireReason() bool {
+	return c != nil && c.Directives.RequireReason
+}
+
+// reportUnused returns true if directives that don't suppress anything must be reported
+func (c *Config) reportUnused() bool {
+	return c != nil && c.Directives.ReportUnused
+}
+
+// skipped returns true if no rule applies to the file because it is a generated file skipped by the configuration or
+// by -skip-generated, which only recognizes the standard header without a configuration
+func (c *Config) skipped(file *ast.File, filename string, skipGenerated bool) bool {
+	if c == nil {
+		return skipGenerated && ast.IsGenerated(file)
+	}
+
+	return (skipGenerated || c.Generated.Skip) && c.Generated.isGenerated(file, filename)
+}
+
+// isGenerated returns true if the file has the standard generated header or matches the configured patterns
+func (g *Generated) isGenerated(file *ast.File, filename string) bool {
+	if ast.IsGenerated(file) {
+		return true
+	}
+
+	for _, pattern := range g.Files {
+		if pathmatch.Match(pattern, filename) {
+			return true
+		}
+	}
+
+	if len(g.headers) == 0 {
+		return false
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			for _, re := range g.headers {
+				if re.MatchString(comment.Text) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func isTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.go")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package durationcheck_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charithe/durationcheck"
+)
+
+func TestConfigSeverity(t *testing.T) {
+	cfg, err := durationcheck.LoadConfig(filepath.Join("testdata", "config", "tests.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		rule     string
+		filename string
+		want     string
+	}{
+		{rule: durationcheck.RuleMul, filename: "a.go", want: durationcheck.SeverityError},
+		{rule: durationcheck.RuleNames, filename: "a.go", want: durationcheck.SeverityWarning},
+		{rule: durationcheck.RuleNames, filename: "a_test.go", want: durationcheck.SeverityWarning},
+		{rule: durationcheck.RuleBitwise, filename: "a.go", want: durationcheck.SeverityError},
+		{rule: durationcheck.RuleBitwise, filename: "a_test.go", want: durationcheck.SeverityInfo},
+	}
+
+	for _, tc := range testCases {
+		if got := cfg.Severity(tc.rule, tc.filename); got != tc.want {
+			t.Errorf("Severity(%q, %q) = %q, want %q", tc.rule, tc.filename, got, tc.want)
+		}
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	testCases := map[string]string{
+		"unknown rule":     "enable: [nope]\n",
+		"unknown severity": "severity:\n  mul: fatal\n",
+		"unknown field":    "enabled: [mul]\n",
+		"tests rule":       "tests:\n  disable: [nope]\n",
+		"bad pattern":      "exemptions:\n  - paths: ['[']\n    rules: [mul]\n",
+		"bad header":       "generated:\n  headers: ['(']\n",
+		"missing catalog":  "messages:\n  catalog: missing.yml\n",
+	}
+
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "durationcheck.yml")
+			if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := durationcheck.LoadConfig(filename); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadConfigCatalogArguments(t *testing.T) {
+	_, err := durationcheck.LoadConfig(filepath.Join("testdata", "config", "messages-invalid.yml"))
+	if err == nil || !strings.Contains(err.Error(), "doesn't have the 1 arguments") {
+		t.Errorf("got error %v, want a mismatch of the arguments", err)
+	}
+}
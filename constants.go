@@ -0,0 +1,175 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+	"strings"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkZeroConstant reports constant duration expressions that evaluate to zero without any of their operands being
+// zero, e.g. `time.Second / 1024 / 1024 / 1024`, which cause busy loops and immediate timeouts
+func (c *checker) checkZeroConstant(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	tv := c.pass.TypesInfo.Types[expr]
+	if tv.Value == nil || !c.classifier.IsDuration(tv.Type) || constant.Sign(tv.Value) != 0 {
+		return
+	}
+
+	// the enclosing constant expression is checked instead
+	for parent := cur.Parent(); ; parent = parent.Parent() {
+		node, ok := parent.Node().(ast.Expr)
+		if !ok {
+			break
+		}
+		if _, ok := node.(*ast.ParenExpr); !ok {
+			if c.pass.TypesInfo.Types[node].Value != nil {
+				return
+			}
+			break
+		}
+	}
+
+	if c.hasZeroOperand(expr) {
+		return
+	}
+
+	c.reportf(RuleZeroConst, expr, "Constant duration `%s` evaluates to zero", c.formatExpr(expr))
+}
+
+// maxDuration is the largest duration, in nanoseconds
+var maxDuration = constant.MakeInt64(math.MaxInt64)
+
+// checkConstantOverflow reports multiplications whose constant factors alone exceed the range of durations, e.g.
+// `time.Duration(n) * time.Hour * 3000000`, which overflows for any count but zero. Constant expressions overflowing
+// don't compile, the check is about those mixing constants with a variable.
+func (c *checker) checkConstantOverflow(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL || !isOutermostMultiplication(cur) {
+		return
+	}
+
+	tv := c.pass.TypesInfo.Types[expr]
+	if tv.Value != nil || !c.classifier.IsDuration(tv.Type) {
+		return
+	}
+
+	var factors []ast.Expr
+	product := constant.MakeInt64(1)
+	for _, op := range multiplicationOperands(expr) {
+		if value := c.pass.TypesInfo.Types[op].Value; value != nil {
+			factors = append(factors, op)
+			product = constant.BinaryOp(product, token.MUL, value)
+		}
+	}
+
+	if len(factors) < 2 || constant.Compare(constant.UnaryOp(token.SUB, product, 0), token.LEQ, maxDuration) &&
+		constant.Compare(product, token.LEQ, maxDuration) {
+		return
+	}
+
+	names := make([]string, len(factors))
+	for i, f := range factors {
+		names[i] = c.formatExpr(f)
+	}
+
+	c.reportf(RuleOverflow, expr, "Duration `%s` overflows: its constant factors `%s` alone amount to %s nanoseconds, beyond the range of time.Duration",
+		c.formatExpr(expr), strings.Join(names, " * "), product.ExactString())
+}
+
+// hasZeroOperand returns true if a literal or a named constant of the expression is zero, making a zero result
+// intentional, e.g. `0 * time.Second`
+func (c *checker) hasZeroOperand(expr ast.Expr) bool {
+	zero := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BasicLit, *ast.Ident, *ast.SelectorExpr:
+			if value := c.pass.TypesInfo.Types[n.(ast.Expr)].Value; value != nil && constant.Sign(value) == 0 {
+				zero = true
+			}
+			return false
+		}
+
+		return !zero
+	})
+
+	return zero
+}
+
+// checkConstantFraction reports integer divisions of untyped constants that discard a remainder inside duration
+// arithmetic, e.g. `d * (1 / 2)` which is zero, or `d * (3 / 10 * 10)`, which truncate before the duration is scaled
+func (c *checker) checkConstantFraction(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	if expr.Op != token.QUO || !c.isUntypedConstant(expr) {
+		return
+	}
+
+	x, y := c.pass.TypesInfo.Types[expr.X].Value, c.pass.TypesInfo.Types[expr.Y].Value
+	if x == nil || y == nil || x.Kind() != constant.Int || y.Kind() != constant.Int || constant.Sign(y) == 0 {
+		return
+	}
+
+	if constant.Sign(constant.BinaryOp(x, token.REM, y)) == 0 {
+		return
+	}
+
+	// look for the duration arithmetic enclosing the constant expression
+	parent := cur.Parent()
+	for {
+		node, ok := parent.Node().(ast.Expr)
+		if !ok {
+			return
+		}
+		if _, ok := node.(*ast.ParenExpr); !ok && !c.isUntypedConstant(node) {
+			break
+		}
+		parent = parent.Parent()
+	}
+
+	switch p := parent.Node().(type) {
+	case *ast.BinaryExpr:
+		if !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(p)) {
+			return
+		}
+	case *ast.CallExpr:
+		if !c.classifier.IsConversion(p) {
+			return
+		}
+	default:
+		return
+	}
+
+	c.reportf(RuleConstFraction, expr, "Constant division `%s` truncates to `%s` before the duration arithmetic: multiply first or use float math",
+		c.formatExpr(expr), constant.BinaryOp(x, token.QUO_ASSIGN, y))
+}
+
+// isUntypedConstant returns true if the expression only combines literals and untyped named constants, whose
+// arithmetic is exact until it meets a typed operand
+func (c *checker) isUntypedConstant(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.ParenExpr:
+		return c.isUntypedConstant(e.X)
+	case *ast.UnaryExpr:
+		return c.isUntypedConstant(e.X)
+	case *ast.BinaryExpr:
+		return c.isUntypedConstant(e.X) && c.isUntypedConstant(e.Y)
+	case *ast.Ident, *ast.SelectorExpr:
+		ident, ok := e.(*ast.Ident)
+		if !ok {
+			ident = e.(*ast.SelectorExpr).Sel
+		}
+
+		obj, ok := c.pass.TypesInfo.ObjectOf(ident).(*types.Const)
+		if !ok {
+			return false
+		}
+
+		basic, ok := obj.Type().(*types.Basic)
+		return ok && basic.Info()&types.IsUntyped != 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,120 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// dataflow traces the origin of the operands of multiplications through the SSA form of the package, built like the
+// buildssa analyzer does, so that durations converted from counts stay counts when stored in local variables, e.g.
+// d in `d := time.Duration(n); d * time.Second`, while durations laundered through integers keep their unit, e.g.
+// `ms := int64(elapsed); time.Duration(ms) * time.Second`
+type dataflow struct {
+	// products indexes the multiplications of the package by the position of their operator
+	products map[token.Pos]*ssa.BinOp
+	// classifier tells durations from other values
+	classifier *durationexpr.Classifier
+}
+
+func newDataflow(pass *analysis.Pass, classifier *durationexpr.Classifier) *dataflow {
+	prog := ssa.NewProgram(pass.Fset, 0)
+	for _, p := range pass.Pkg.Imports() {
+		prog.CreatePackage(p, nil, nil, true)
+	}
+
+	pkg := prog.CreatePackage(pass.Pkg, pass.Files, pass.TypesInfo, false)
+	pkg.Build()
+
+	df := &dataflow{products: map[token.Pos]*ssa.BinOp{}, classifier: classifier}
+
+	var index func(fn *ssa.Function)
+	index = func(fn *ssa.Function) {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				if op, ok := instr.(*ssa.BinOp); ok && op.Op == token.MUL {
+					df.products[op.Pos()] = op
+				}
+			}
+		}
+
+		for _, anon := range fn.AnonFuncs {
+			index(anon)
+		}
+	}
+
+	// package-level variables are initialized by the init function
+	if init := pkg.Func("init"); init != nil {
+		index(init)
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok {
+				if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+					if f := prog.FuncValue(fn); f != nil {
+						index(f)
+					}
+				}
+			}
+		}
+	}
+
+	return df
+}
+
+// operandKind classifies an operand of the multiplication by the origin of its value. It returns false if the
+// multiplication isn't in the SSA form, e.g. because it was folded into a constant.
+func (df *dataflow) operandKind(product *ast.BinaryExpr, operand ast.Expr) (durationexpr.Kind, bool) {
+	op, ok := df.products[product.OpPos]
+	if !ok {
+		return durationexpr.Unit, false
+	}
+
+	v := op.Y
+	if operand == product.X {
+		v = op.X
+	}
+
+	if df.carriesUnit(v, map[ssa.Value]bool{}) {
+		return durationexpr.Unit, true
+	}
+
+	return durationexpr.Count, true
+}
+
+// carriesUnit returns true if the value derives from a duration that isn't a conversion of a count: conversions and
+// arithmetic are traced to their operands, other values carry a unit if they are durations, e.g. a call to time.Since,
+// a parameter or a constant like time.Second
+func (df *dataflow) carriesUnit(v ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	switch v := v.(type) {
+	case *ssa.Convert:
+		return df.carriesUnit(v.X, visited)
+	case *ssa.ChangeType:
+		return df.carriesUnit(v.X, visited)
+	case *ssa.BinOp:
+		return df.carriesUnit(v.X, visited) || df.carriesUnit(v.Y, visited)
+	case *ssa.UnOp:
+		if v.Op == token.SUB {
+			return df.carriesUnit(v.X, visited)
+		}
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if df.carriesUnit(edge, visited) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return df.classifier.IsDuration(v.Type())
+}
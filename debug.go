@@ -0,0 +1,53 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charithe/durationcheck/durationexpr"
+)
+
+var (
+	// debugOutput receives the -debug messages
+	debugOutput io.Writer = os.Stderr
+	// debugMu serializes the messages of packages analyzed concurrently
+	debugMu sync.Mutex
+)
+
+// debugOperands writes with -debug how the operands of a multiplication of durations were classified, whether it is
+// reported or not, e.g.
+//
+//	main.go:12:6: debug: `retries` in `retries * time.Second`: time.Duration unacceptable, variable of type time.Duration
+func (c *checker) debugOperands(product ast.Expr, operands []Operand, reasons []string) {
+	if !c.settings.debug {
+		return
+	}
+
+	expr := c.formatExpr(product)
+
+	var buf strings.Builder
+	for i, op := range operands {
+		reason := reasons[i]
+		if reason == "" {
+			reason = c.classifier.Explain(op.Expr).Reason
+		}
+
+		verdict := "acceptable"
+		if op.Kind == durationexpr.Unit {
+			verdict = "unacceptable"
+		}
+
+		fmt.Fprintf(&buf, "%s: debug: `%s` in `%s`: %s %s, %s\n", c.pass.Fset.Position(op.Expr.Pos()), c.formatExpr(op.Expr), expr, op.Type, verdict, reason)
+	}
+
+	debugMu.Lock()
+	defer debugMu.Unlock()
+
+	if _, err := io.WriteString(debugOutput, buf.String()); err != nil {
+		c.fail(fmt.Errorf("writing -debug messages: %w", err))
+	}
+}
@@ -0,0 +1,35 @@
+package durationcheck
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDebug(t *testing.T) {
+	var buf bytes.Buffer
+	debugOutput = &buf
+	t.Cleanup(func() { debugOutput = os.Stderr })
+
+	s := newSettings()
+	s.debug = true
+	s.acceptNames = stringsFlag{"retries"}
+
+	analysistest.Run(t, analysistest.TestData(), newAnalyzer(s), "debugging")
+
+	want := "debugging.go:6:6: debug: `timeout` in `timeout * time.Second`: time.Duration unacceptable, variable of type time.Duration\n" +
+		"debugging.go:6:16: debug: `time.Second` in `timeout * time.Second`: time.Duration unacceptable, constant of type time.Duration\n" +
+		"debugging.go:8:6: debug: `time.Duration(n)` in `time.Duration(n) * time.Second`: time.Duration acceptable, conversion of a plain number\n" +
+		"debugging.go:8:25: debug: `time.Second` in `time.Duration(n) * time.Second`: time.Duration unacceptable, constant of type time.Duration\n" +
+		"debugging.go:10:6: debug: `retries` in `retries * time.Second`: time.Duration acceptable, named with a word of -accept-names\n" +
+		"debugging.go:10:16: debug: `time.Second` in `retries * time.Second`: time.Duration unacceptable, constant of type time.Duration\n"
+
+	dir := filepath.Join(analysistest.TestData(), "src", "debugging") + string(filepath.Separator)
+	if got := strings.ReplaceAll(buf.String(), dir, ""); got != want {
+		t.Errorf("got debug messages:\n%s\nwant:\n%s", got, want)
+	}
+}
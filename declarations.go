@@ -0,0 +1,49 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fixUnits are the units suggested to scale bare numbers with, the most likely first
+var fixUnits = []string{"Second", "Millisecond", "Minute"}
+
+// checkBareInitialization reports explicitly typed duration declarations initialized with bare numbers above the
+// -unscaled-threshold, e.g. `const defaultTimeout time.Duration = 30` which is 30 nanoseconds, and suggests scaling
+// them by the common units
+func (c *checker) checkBareInitialization(spec *ast.ValueSpec) {
+	if spec.Type == nil || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(spec.Type)) {
+		return
+	}
+
+	qualifier, ok := unitQualifier(spec.Type)
+	if !ok {
+		return
+	}
+
+	for i, value := range spec.Values {
+		if i >= len(spec.Names) || !isNumberLiteral(value) {
+			continue
+		}
+
+		n, exact := constant.Int64Val(constant.ToInt(c.pass.TypesInfo.Types[value].Value))
+		if !exact || n <= int64(c.settings.unscaledThreshold) && n >= -int64(c.settings.unscaledThreshold) {
+			continue
+		}
+
+		literal := formatNode(value)
+		fixes := make([]analysis.SuggestedFix, 0, len(fixUnits))
+		for _, unit := range fixUnits {
+			replacement := literal + " * " + qualifier + "." + unit
+			fixes = append(fixes, analysis.SuggestedFix{
+				Message:   "Scale by " + qualifier + "." + unit,
+				TextEdits: []analysis.TextEdit{{Pos: value.Pos(), End: value.End(), NewText: []byte(replacement)}},
+			})
+		}
+
+		c.reportFixf(RuleBareInit, value, fixes, "Duration `%s` initialized with bare number `%s` is %d nanoseconds: multiply it by a unit",
+			spec.Names[i].Name, c.formatExpr(value), n)
+	}
+}
@@ -0,0 +1,245 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+const directivePrefix = "//durationcheck:ignore"
+
+// nolintPrefix starts the golangci-lint directives, e.g. `//nolint:durationcheck // reason`
+const nolintPrefix = "//nolint"
+
+// Modes of the -nolint flag.
+const (
+	// nolintOff ignores nolint directives, golangci-lint applies them itself
+	nolintOff = "off"
+	// nolintRespect suppresses the findings on the lines of nolint directives naming durationcheck
+	nolintRespect = "respect"
+	// nolintDirective handles nolint directives naming durationcheck like `//durationcheck:ignore` ones: they may
+	// require a reason and be reported when unused
+	nolintDirective = "directive"
+)
+
+// categoryDirective is the category of the diagnostics about the directives themselves
+const categoryDirective = "directive"
+
+// directive is a `//durationcheck:ignore` comment suppressing the findings on its line or on the next one:
+//
+//	//durationcheck:ignore until=2025-06-30 reason=squared jitter is intentional
+type directive struct {
+	pos token.Pos
+	// used is set once the directive suppressed a finding
+	used bool
+	// until is the last day the directive is effective on, formatted as YYYY-MM-DD. It is empty if it never expires.
+	until  string
+	reason string
+	// nolint is set for the nolint directives respected without being handled as durationcheck ones
+	nolint bool
+}
+
+// expired returns true if the directive stopped being effective
+func (d *directive) expired() bool {
+	return d.until != "" && time.Now().Format(time.DateOnly) > d.until
+}
+
+// parseDirective parses a comment, returning nil if it isn't a directive
+func parseDirective(comment *ast.Comment, requireReason bool) (*directive, error) {
+	text, ok := strings.CutPrefix(comment.Text, directivePrefix)
+	if !ok || text != "" && text[0] != ' ' && text[0] != '\t' {
+		return nil, nil
+	}
+
+	d := &directive{pos: comment.Pos()}
+
+	// anything after a nested comment marker is an explanation, like for nolint directives
+	text, _, _ = strings.Cut(text, "//")
+	text = strings.TrimSpace(text)
+	for text != "" {
+		if reason, ok := strings.CutPrefix(text, "reason="); ok {
+			// the reason is free text spanning the rest of the comment
+			d.reason = strings.TrimSpace(reason)
+			break
+		}
+
+		field, rest, _ := strings.Cut(text, " ")
+		text = strings.TrimSpace(rest)
+
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "until":
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				return nil, fmt.Errorf("invalid until date %q, expected YYYY-MM-DD", value)
+			}
+			d.until = value
+		default:
+			return nil, fmt.Errorf("unknown option %q", field)
+		}
+	}
+
+	if requireReason && d.reason == "" {
+		return nil, fmt.Errorf("a reason=... is required")
+	}
+
+	return d, nil
+}
+
+// parseNolint parses a golangci-lint nolint comment, returning nil if it isn't one or doesn't name durationcheck. The
+// explanation following a nested comment marker is the reason of the directive.
+func parseNolint(comment *ast.Comment, mode string, requireReason bool) (*directive, error) {
+	text, ok := strings.CutPrefix(comment.Text, nolintPrefix)
+	if !ok || mode == nolintOff {
+		return nil, nil
+	}
+
+	text, reason, _ := strings.Cut(text, "//")
+	if linters, ok := strings.CutPrefix(text, ":"); ok {
+		linters, _, _ = strings.Cut(linters, " ")
+
+		named := false
+		for _, linter := range strings.Split(linters, ",") {
+			named = named || linter == "durationcheck" || linter == "all"
+		}
+
+		if !named {
+			return nil, nil
+		}
+	} else if text != "" && text[0] != ' ' && text[0] != '\t' {
+		return nil, nil
+	}
+
+	d := &directive{pos: comment.Pos(), reason: strings.TrimSpace(reason), nolint: mode == nolintRespect}
+
+	if requireReason && !d.nolint && d.reason == "" {
+		return nil, fmt.Errorf("a // reason is required")
+	}
+
+	return d, nil
+}
+
+// parseDirectives returns the directives of the file indexed by line. Invalid directives are reported, see
+// reportsInvalidDirectives.
+func (c *checker) parseDirectives(file *ast.File) map[int]*directive {
+	var directives map[int]*directive
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			d, err := parseDirective(comment, c.config.requireReason())
+			if err != nil {
+				if c.reportsInvalidDirectives() {
+					c.report(analysis.Diagnostic{
+						Pos:      comment.Pos(),
+						End:      comment.End(),
+						Category: categoryDirective,
+						Message:  fmt.Sprintf(c.config.translate("Invalid durationcheck:ignore directive: %v"), err),
+					})
+				}
+				continue
+			}
+
+			if d == nil {
+				if d, err = parseNolint(comment, c.settings.nolintMode, c.config.requireReason()); err != nil {
+					if c.reportsInvalidDirectives() {
+						c.report(analysis.Diagnostic{
+							Pos:      comment.Pos(),
+							End:      comment.End(),
+							Category: categoryDirective,
+							Message:  fmt.Sprintf(c.config.translate("Invalid nolint directive: %v"), err),
+						})
+					}
+					continue
+				}
+			}
+
+			if d == nil {
+				continue
+			}
+
+			if directives == nil {
+				directives = make(map[int]*directive)
+			}
+			directives[c.pass.Fset.Position(comment.Pos()).Line] = d
+		}
+	}
+
+	return directives
+}
+
+// suppression returns the directive applying to the node, if any, and marks it as used. A directive applies to the
+// nodes starting on its line or on the next one, and to the whole of a statement spanning several lines, e.g. to the
+// arguments of a multi-line call.
+func (c *checker) suppression(node ast.Node) *directive {
+	line := c.pass.Fset.Position(node.Pos()).Line
+	if d := c.lineSuppression(line); d != nil {
+		return d
+	}
+
+	if c.file == nil {
+		return nil
+	}
+
+	path, _ := astutil.PathEnclosingInterval(c.file, node.Pos(), node.End())
+	for _, n := range path {
+		switch n.(type) {
+		case ast.Stmt, *ast.ValueSpec:
+			if start := c.pass.Fset.Position(n.Pos()).Line; start != line {
+				return c.lineSuppression(start)
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// lineSuppression returns the directive applying to the nodes starting on the line, if any, and marks it as used
+func (c *checker) lineSuppression(line int) *directive {
+	d, ok := c.directives[line]
+	if !ok {
+		d, ok = c.directives[line-1]
+	}
+
+	if ok {
+		d.used = true
+	}
+
+	return d
+}
+
+// reportsInvalidDirectives returns true if the analyzer reports the invalid directives: the combined analyzers, and
+// of the analyzers of the rules only that of the first one, so that drivers running them all report them once
+func (c *checker) reportsInvalidDirectives() bool {
+	return c.only == nil || c.only == rules[0]
+}
+
+// reportUnusedDirectives reports the directives of the file being checked that didn't suppress any finding. The
+// analyzer of a single rule doesn't, as another rule may use the directive.
+func (c *checker) reportUnusedDirectives() {
+	if !c.config.reportUnused() || c.only != nil {
+		return
+	}
+
+	var unused []*directive
+	for _, d := range c.directives {
+		if !d.used && !d.nolint {
+			unused = append(unused, d)
+		}
+	}
+
+	sort.Slice(unused, func(i, j int) bool { return unused[i].pos < unused[j].pos })
+
+	for _, d := range unused {
+		c.report(analysis.Diagnostic{
+			Pos:      d.pos,
+			Category: categoryDirective,
+			Message:  c.config.translate("Unused durationcheck:ignore directive"),
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestParseNolint(t *testing.T) {
+	testCases := []struct {
+		text          string
+		mode          string
+		requireReason bool
+		want          *directive
+		wantErr       bool
+	}{
+		{text: "//nolint:durationcheck", mode: nolintOff},
+		{text: "//nolint:durationcheck", mode: nolintRespect, want: &directive{nolint: true}},
+		{text: "//nolint", mode: nolintRespect, want: &directive{nolint: true}},
+		{text: "//nolint:all", mode: nolintRespect, want: &directive{nolint: true}},
+		{text: "//nolint:gosec,durationcheck", mode: nolintRespect, want: &directive{nolint: true}},
+		{text: "//nolint:gosec", mode: nolintRespect},
+		{text: "//nolintlint", mode: nolintRespect},
+		{text: "//nolint:durationcheck", mode: nolintRespect, requireReason: true, want: &directive{nolint: true}},
+		{text: "//nolint:durationcheck // legacy API", mode: nolintDirective, want: &directive{reason: "legacy API"}},
+		{text: "//nolint:durationcheck", mode: nolintDirective, requireReason: true, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		d, err := parseNolint(&ast.Comment{Text: tc.text}, tc.mode, tc.requireReason)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseNolint(%q, %s): got error %v", tc.text, tc.mode, err)
+			continue
+		}
+
+		if (d == nil) != (tc.want == nil) || d != nil && *d != *tc.want {
+			t.Errorf("parseNolint(%q, %s) = %+v, want %+v", tc.text, tc.mode, d, tc.want)
+		}
+	}
+}
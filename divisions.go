@@ -0,0 +1,103 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkDivisionBeforeScaling reports integers divided before being converted to durations and scaled by a unit when
+// a finer unit makes the division unnecessary, e.g. `time.Duration(ms/1000) * time.Second` which floors to whole
+// seconds instead of `time.Duration(ms) * time.Millisecond`
+func (c *checker) checkDivisionBeforeScaling(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL {
+		return
+	}
+
+	for _, operands := range [][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+		quo := c.integerDivisionArg(operands[0])
+		if quo == nil {
+			continue
+		}
+
+		unit := operands[1]
+		qualifier, ok := unitQualifier(unit)
+		if !ok || !isUnitConstant(c.pass, unit) {
+			continue
+		}
+
+		divisor, ok1 := constant.Int64Val(constant.ToInt(c.pass.TypesInfo.Types[quo.Y].Value))
+		value, ok2 := constant.Int64Val(c.pass.TypesInfo.Types[unit].Value)
+		if !ok1 || !ok2 || divisor <= 1 || value%divisor != 0 || addsRemainder(cur, quo) {
+			continue
+		}
+
+		for _, u := range units {
+			if u.value == value/divisor {
+				c.reportf(RuleIntDivision, expr, "Integer division `%s` truncates before scaling by %s: use `time.Duration(%s) * %s.%s`",
+					c.formatExpr(quo), c.formatExpr(unit), c.formatExpr(quo.X), qualifier, u.name)
+				return
+			}
+		}
+	}
+}
+
+// integerDivisionArg returns the argument of a conversion to time.Duration if it is the division of a non-constant
+// integer by a constant, or nil
+func (c *checker) integerDivisionArg(expr ast.Expr) *ast.BinaryExpr {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !c.classifier.IsConversion(call) {
+		return nil
+	}
+
+	quo, ok := ast.Unparen(call.Args[0]).(*ast.BinaryExpr)
+	if !ok || quo.Op != token.QUO {
+		return nil
+	}
+
+	tv, ok := c.pass.TypesInfo.Types[quo]
+	if !ok || tv.Value != nil || c.pass.TypesInfo.Types[quo.Y].Value == nil {
+		return nil
+	}
+
+	if !isInteger(tv.Type) || c.classifier.IsDuration(tv.Type) {
+		return nil
+	}
+
+	return quo
+}
+
+// addsRemainder reports whether the scaled quotient is added to the remainder of the same division, e.g.
+// `time.Duration(ms/1000)*time.Second + time.Duration(ms%1000)*time.Millisecond`, which is exact
+func addsRemainder(cur inspector.Cursor, quo *ast.BinaryExpr) bool {
+	parent := cur.Parent()
+	for {
+		if _, ok := parent.Node().(*ast.ParenExpr); !ok {
+			break
+		}
+		parent = parent.Parent()
+	}
+
+	sum, ok := parent.Node().(*ast.BinaryExpr)
+	if !ok || sum.Op != token.ADD {
+		return false
+	}
+
+	other := sum.X
+	if ast.Unparen(other).Pos() == cur.Node().Pos() {
+		other = sum.Y
+	}
+
+	dividend, divisor := formatNode(quo.X), formatNode(quo.Y)
+	found := false
+	ast.Inspect(other, func(n ast.Node) bool {
+		if rem, ok := n.(*ast.BinaryExpr); ok && rem.Op == token.REM && formatNode(rem.X) == dividend && formatNode(rem.Y) == divisor {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,48 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// checkDoubleConversion reports products of two variables both converted to durations, e.g.
+// `time.Duration(timeout) * time.Duration(interval)`: multiplying two quantities rarely makes sense, so one of them
+// almost always carried a unit already
+func (c *checker) checkDoubleConversion(expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL {
+		return
+	}
+
+	x, xOK := c.convertedVariable(expr.X)
+	y, yOK := c.convertedVariable(expr.Y)
+	if !xOK || !yOK {
+		return
+	}
+
+	c.reportf(RuleDoubleCast, expr, "Product of the durations converted from variables `%s` and `%s`: at most one of them should be a count",
+		c.formatExpr(x), c.formatExpr(y))
+}
+
+// convertedVariable returns the variable converted by a conversion to time.Duration, e.g. n for `time.Duration(n)`
+func (c *checker) convertedVariable(expr ast.Expr) (ast.Expr, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !c.classifier.IsConversion(call) {
+		return nil, false
+	}
+
+	arg := ast.Unparen(call.Args[0])
+
+	var ident *ast.Ident
+	switch e := arg.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil, false
+	}
+
+	_, ok = c.pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	return arg, ok
+}
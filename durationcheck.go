@@ -7,36 +7,100 @@ import (
 	"go/format"
 	"go/token"
 	"go/types"
-	"log"
-	"os"
+	"strings"
 
+	"github.com/charithe/durationcheck/durationexpr"
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
-var Analyzer = &analysis.Analyzer{
-	Name:     "durationcheck",
-	Doc:      "check for two durations multiplied together",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+// defaultSettings are the settings shared by Analyzer and StandaloneAnalyzer
+var defaultSettings = newSettings()
+
+var Analyzer = newAnalyzer(defaultSettings)
+
+// StandaloneAnalyzer performs the same checks as Analyzer but walks the syntax trees itself instead of
+// requiring the inspect analyzer. It is meant for minimal drivers that run a single analysis pass.
+var StandaloneAnalyzer = newStandaloneAnalyzer(defaultSettings)
+
+var nodeTypes = []ast.Node{
+	(*ast.BinaryExpr)(nil),
+	(*ast.ReturnStmt)(nil),
+	(*ast.AssignStmt)(nil),
+	(*ast.CallExpr)(nil),
+	(*ast.ValueSpec)(nil),
+	(*ast.CompositeLit)(nil),
+	(*ast.FuncDecl)(nil),
+	(*ast.TypeSpec)(nil),
 }
 
-func run(pass *analysis.Pass) (interface{}, error) {
-	// if the package does not import time, it can be skipped from analysis
-	if !hasImport(pass.Pkg, "time") {
-		return nil, nil
+// check runs the enabled checks over the files of the package, or only the given rule if not nil. The inspector is
+// built from the files if nil, and the kinds of the results of the functions of the package are classified if results
+// is nil, without those of other packages, as are the duration parameters they multiply if products is nil.
+func check(pass *analysis.Pass, s *settings, only *rule, inspect *inspector.Inspector, timeouts timeoutParams, results map[*types.Func]durationexpr.Kind, products paramProducts) (interface{}, error) {
+	c, err := newChecker(pass, s)
+	if err != nil {
+		return nil, err
 	}
+	c.only = only
+	c.timeouts = timeouts
 
-	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if results == nil && pass.TypesInfo != nil {
+		results = map[*types.Func]durationexpr.Kind{}
+		classifyResults(pass, results)
+	}
+	c.classifier.Results = results
 
-	nodeTypes := []ast.Node{
-		(*ast.BinaryExpr)(nil),
+	if products == nil && pass.TypesInfo != nil {
+		products = paramProducts{}
+		findParamProducts(pass.TypesInfo, pass.Files, products)
 	}
+	c.products = products
+
+	// if no expression of the package is a duration, it can be skipped from analysis unless a rule looking for
+	// integers that should have been durations is enabled, and so can the files without durations, see setFile
+	if len(c.durationFiles) == 0 && !c.anyPackageRuleEnabled() {
+		return c.result, nil
+	}
+
+	if inspect == nil {
+		inspect = inspector.New(pass.Files)
+	}
+
+	for cur := range inspect.Root().Children() {
+		if !c.setFile(cur.Node().(*ast.File)) {
+			continue
+		}
+
+		for n := range cur.Preorder(nodeTypes...) {
+			c.check(n)
+		}
+
+		c.reportUnusedDirectives()
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.result, nil
+}
+
+// durationFiles returns the files of the package where an expression is a duration. Unlike checking whether a file
+// imports time, it also catches durations obtained through other packages, e.g. `client.Timeout * factor`.
+func durationFiles(fset *token.FileSet, info *types.Info, classifier *durationexpr.Classifier) map[*token.File]bool {
+	files := map[*token.File]bool{}
+	for expr, tv := range info.Types {
+		if !classifier.IsDuration(tv.Type) {
+			continue
+		}
 
-	inspect.Preorder(nodeTypes, check(pass))
+		if f := fset.File(expr.Pos()); f != nil {
+			files[f] = true
+		}
+	}
 
-	return nil, nil
+	return files
 }
 
 func hasImport(pkg *types.Package, importPath string) bool {
@@ -49,132 +113,681 @@ func hasImport(pkg *types.Package, importPath string) bool {
 	return false
 }
 
+// checker holds the state of the checks for a single analysis pass
+type checker struct {
+	pass       *analysis.Pass
+	settings   *settings
+	classifier *durationexpr.Classifier
+	config     *Config
+	// only is the rule of the analyzer of a single rule, see Analyzers, nil for the combined analyzers
+	only *rule
+
+	// durationFiles holds the files where an expression has type time.Duration, see durationFiles
+	durationFiles map[*token.File]bool
+	// unitAPIs maps the functions of -unit-apis to the unit of their integer parameters
+	unitAPIs map[string]string
+	// wrappers are the duration wrapper types of the -profiles
+	wrappers []wrapperType
+	// timeouts holds the integer parameters named like durations of the functions of other packages
+	timeouts timeoutParams
+	// products holds the duration parameters multiplied together by the functions of the package and of the others
+	products paramProducts
+
+	// genericProducts indexes the multiplications of operands typed by type parameters of the generic functions of
+	// the package, built on first use
+	genericProducts map[*types.Func][]genericProduct
+	// acceptNames are the words naming the duration variables treated as counts, see -accept-names
+	acceptNames []string
+	// excludedExprs are the patterns of -exclude-expr
+	excludedExprs []excludedExpr
+	// parsedVars maps the variables holding durations parsed from strings to the function parsing them, built on first
+	// use, see parsedVarsOf
+	parsedVars map[types.Object]string
+	// dataflow traces the operands of multiplications, built on first use with -dataflow
+	dataflow *dataflow
+
+	// result holds the findings reported for the package
+	result *Result
+	// err is the first error of the analysis, see fail
+	err error
+
+	// file is the file being checked
+	file *ast.File
+	// enabled holds the rules enabled for the file being checked
+	enabled map[string]bool
+	// directives holds the suppression directives of the file being checked, indexed by line
+	directives map[int]*directive
+}
+
+func newChecker(pass *analysis.Pass, s *settings) (*checker, error) {
+	c := &checker{
+		pass:     pass,
+		settings: s,
+		result:   &Result{},
+		classifier: &durationexpr.Classifier{
+			Info:          pass.TypesInfo,
+			MaxCountConst: int64(s.maxCountConst),
+		},
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	apis, err := parseUnitAPIs(s.unitAPIs)
+	if err != nil {
+		return nil, err
+	}
+	c.unitAPIs = apis
+
+	if c.wrappers, err = parseProfiles(s.profileNames); err != nil {
+		return nil, err
+	}
+
+	if c.excludedExprs, err = parseExcludedExprs(s.excludeExprs); err != nil {
+		return nil, err
+	}
+
+	if c.config, err = s.loadConfig(); err != nil {
+		return nil, err
+	}
+
+	c.classifier.Types = c.config.durationTypes(s.durationTypes)
+	c.acceptNames = c.config.acceptNames(s.acceptNames)
+	c.durationFiles = durationFiles(pass.Fset, pass.TypesInfo, c.classifier)
+
+	return c, nil
+}
+
+// anyPackageRuleEnabled returns true if a rule that applies to packages without durations may be enabled
+func (c *checker) anyPackageRuleEnabled() bool {
+	for _, r := range rules {
+		if r.anyPackage && c.runs(r) && c.config.mayEnable(r.code, c.enabledByDefault(r)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runs returns true if the analyzer runs the rule, every rule for the combined analyzers
+func (c *checker) runs(r *rule) bool {
+	return c.only == nil || c.only == r
+}
+
+// enabledByDefault returns true if the rule is enabled when the configuration doesn't mention it. The analyzer of a
+// single rule enables it whatever the flags.
+func (c *checker) enabledByDefault(r *rule) bool {
+	return c.only == r || c.settings.enabledByDefault(r)
+}
+
+// confident returns true if the confidence of the rule reaches -min-confidence. Like enabledByDefault, the analyzer of
+// a single rule runs it whatever the flags.
+func (c *checker) confident(r *rule) bool {
+	return c.only == r || c.settings.confident(r)
+}
+
+// setFile resolves the rules enabled for the file about to be checked, it returns false if none is
+func (c *checker) setFile(file *ast.File) bool {
+	filename := c.pass.Fset.Position(file.Pos()).Filename
+	if c.settings.testsOnly && !isTestFile(filename) || c.settings.skipTests && isTestFile(filename) {
+		return false
+	}
+
+	if c.settings.excludedFile(filename) || c.config.excludedFile(filename) || c.config.skipped(file, filename, c.settings.skipGenerated) {
+		return false
+	}
+
+	c.file = file
+	durations := c.durationFiles[c.pass.Fset.File(file.Pos())]
+	anyEnabled := false
+	c.enabled = make(map[string]bool, len(rules))
+	for _, r := range rules {
+		enabled := (durations || r.anyPackage) && c.runs(r) && c.confident(r) && c.config.enabled(r.code, filename, c.enabledByDefault(r))
+		c.enabled[r.code] = enabled
+		anyEnabled = anyEnabled || enabled
+	}
+
+	if anyEnabled {
+		c.directives = c.parseDirectives(file)
+	}
+
+	return anyEnabled
+}
+
 // check contains the logic for checking that time.Duration is used correctly in the code being analysed
-func check(pass *analysis.Pass) func(ast.Node) {
-	return func(node ast.Node) {
-		expr := node.(*ast.BinaryExpr)
-		// we are only interested in multiplication
-		if expr.Op != token.MUL {
-			return
+func (c *checker) check(cur inspector.Cursor) {
+	switch node := cur.Node().(type) {
+	case *ast.BinaryExpr:
+		if c.enabled[RuleNames] {
+			c.checkNonTimeConversions(node)
+		}
+
+		if c.enabled[RuleBitwise] {
+			c.checkBitwiseOperation(node)
+		}
+
+		if c.enabled[RuleFloatCount] {
+			c.checkFloatConversion(node)
+		}
+
+		if c.enabled[RuleIntDivision] {
+			c.checkDivisionBeforeScaling(cur, node)
+		}
+
+		if c.enabled[RuleUnitChain] {
+			c.checkUnitChain(cur, node)
+		}
+
+		if c.enabled[RuleSentinel] {
+			c.checkSentinelArithmetic(node)
+		}
+
+		if c.enabled[RuleZeroConst] {
+			c.checkZeroConstant(cur, node)
+		}
+
+		if c.enabled[RuleOverflow] {
+			c.checkConstantOverflow(cur, node)
+		}
+
+		if c.enabled[RuleConstFraction] {
+			c.checkConstantFraction(cur, node)
+		}
+
+		if c.enabled[RuleDoubleCast] {
+			c.checkDoubleConversion(node)
+		}
+
+		if c.enabled[RuleCompareLiteral] {
+			c.checkLiteralComparison(node)
+		}
+
+		if c.enabled[RuleRoundTrip] {
+			c.checkRoundTrip(node)
+		}
+
+		if c.enabled[RuleRedundantUnit] {
+			c.checkRedundantUnit(cur, node)
+		}
+
+		if c.enabled[RuleRawInt] {
+			c.checkRawIntComparison(node)
+		}
+
+		if c.enabled[RuleParsedRescale] {
+			c.checkParsedRescale(cur, node)
+		}
+
+		if c.enabled[RuleMul] {
+			c.checkMultiplication(cur, node)
+		}
+	case *ast.AssignStmt:
+		if c.enabled[RuleMul] {
+			c.checkMultiplicationAssignment(cur, node)
+		}
+
+		if c.enabled[RuleRedundantUnit] {
+			c.checkRedundantUnitAssignment(node)
+		}
+
+		if c.enabled[RuleRawInt] {
+			c.checkRawIntAssignment(node)
+		}
+
+		if c.enabled[RuleParsedRescale] {
+			c.checkParsedRescaleAssignment(node)
+		}
+
+		if c.enabled[RuleSentinel] {
+			c.checkSentinelAssignment(node)
+		}
+
+		if c.enabled[RuleIntAccumulator] {
+			c.checkIntegerAccumulator(node)
+		}
+
+		if c.enabled[RuleUnscaledAdd] {
+			c.checkUnscaledConstant(node)
+		}
+	case *ast.ValueSpec:
+		if c.enabled[RuleBareInit] {
+			c.checkBareInitialization(node)
+		}
+
+		if c.enabled[RuleRawInt] {
+			c.checkRawIntDeclaration(node)
+		}
+	case *ast.CompositeLit:
+		if c.enabled[RuleWrapperInit] {
+			c.checkWrapperLiteral(node)
+		}
+
+		if c.enabled[RuleQuotient] {
+			c.checkQuotientField(node)
 		}
 
-		// get the types of the two operands
-		x, xOK := pass.TypesInfo.Types[expr.X]
-		y, yOK := pass.TypesInfo.Types[expr.Y]
+		if c.enabled[RuleRawInt] {
+			c.checkRawIntField(node)
+		}
+	case *ast.CallExpr:
+		if c.enabled[RuleUnsigned] {
+			c.checkUnsignedConversion(cur, node)
+		}
+
+		if c.enabled[RuleFlagDefault] {
+			c.checkFlagDefault(node)
+		}
+
+		if c.enabled[RuleUnitArgs] {
+			c.checkUnitArguments(node)
+		}
+
+		if c.enabled[RuleDurationpb] {
+			c.checkDurationpb(node)
+		}
+
+		if c.enabled[RuleCalendar] {
+			c.checkCalendarConversion(node)
+		}
+
+		if c.enabled[RuleClockField] {
+			c.checkClockConversion(node)
+		}
+
+		if c.enabled[RuleTimeoutCalls] {
+			c.checkTimeoutCall(node)
+		}
+
+		if c.enabled[RuleSleepLiteral] {
+			c.checkSleepArgument(node)
+		}
 
-		if !xOK || !yOK {
+		if c.enabled[RuleQuotient] {
+			c.checkQuotientArgument(node)
+		}
+
+		if c.enabled[RulePrintf] {
+			c.checkPrintfArguments(node)
+		}
+
+		if c.enabled[RuleUnitSuffix] {
+			c.checkUnitSuffixedConversion(cur, node)
+		}
+
+		if c.enabled[RuleMul] {
+			c.checkGenericInstantiation(node)
+		}
+
+		if c.enabled[RuleMulCalls] {
+			c.checkProductCall(cur, node)
+		}
+
+		if c.enabled[RuleEpochDiff] {
+			c.checkEpochDifference(cur, node)
+		}
+	case *ast.ReturnStmt:
+		if c.enabled[RuleReturnInt] {
+			c.checkReturnedInteger(cur, node)
+		}
+	case *ast.FuncDecl:
+		if c.enabled[RuleIntParams] {
+			c.checkIntegerParams(node)
+		}
+	case *ast.TypeSpec:
+		if c.enabled[RuleTaggedField] {
+			c.checkTaggedFields(node)
+		}
+
+	}
+}
+
+// checkMultiplication reports multiplications where more than one operand already carries a unit of time. Chains
+// such as `a * b * time.Second` are checked as a whole from their outermost multiplication.
+func (c *checker) checkMultiplication(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	// we are only interested in multiplication
+	if expr.Op != token.MUL {
+		return
+	}
+
+	if !isOutermostMultiplication(cur) {
+		return
+	}
+
+	// the truncation idiom `(d / unit) * unit` multiplies a count of units by the unit, FixAnalyzer rewrites it into
+	// `d.Truncate(unit)`
+	if _, _, ok := truncationIdiom(c.pass, expr); ok {
+		return
+	}
+
+	// `d * time.Nanosecond` is an identity, reported as such by redundant-unit
+	if _, ok := c.redundantUnitOperand(expr.X, expr.Op, expr.Y); ok && c.enabled[RuleRedundantUnit] {
+		return
+	}
+
+	operands := multiplicationOperands(expr)
+	products := operandProducts(expr)
+
+	// rescaled parsed durations are reported by parsed-rescale with a message of their own
+	if _, _, _, ok := c.parsedRescale(operands); ok && c.enabled[RuleParsedRescale] {
+		return
+	}
+
+	var units []ast.Expr
+	var found []Operand
+	var reasons []string
+	typed := 0
+	for _, op := range operands {
+		// get the type of the operand
+		tv, ok := c.pass.TypesInfo.Types[op]
+		if !ok {
+			c.incompletef(expr, "the type of an operand is unknown")
 			return
 		}
 
-		if isDuration(x.Type) && isDuration(y.Type) {
-			// check that both sides are acceptable expressions
-			if isUnacceptableExpr(pass, expr.X) && isUnacceptableExpr(pass, expr.Y) {
-				pass.Reportf(expr.Pos(), "Multiplication of durations: `%s`", formatNode(expr))
-			}
+		if !c.classifier.IsDuration(tv.Type) {
+			return
+		}
+
+		if !c.isUntypedConstant(op) {
+			typed++
+		}
+
+		// check that the operand is an acceptable expression
+		kind, reason := c.classify(products[op], op, tv)
+		if kind == durationexpr.Unit {
+			units = append(units, op)
+		}
+		found = append(found, Operand{Expr: op, Type: tv.Type, Kind: kind})
+		reasons = append(reasons, reason)
+	}
+
+	c.debugOperands(expr, found, reasons)
+
+	if len(units) < 2 {
+		if c.settings.strict && typed >= 2 && c.reportf(RuleMul, expr, "Multiplication of durations in strict mode: `%s`", c.formatExpr(expr)) {
+			c.setOperands(expr, found)
+		}
+		return
+	}
+
+	// products of statistics, e.g. squared deviations converted to floats, aren't durations
+	if c.settings.usageContext && !c.usedAsDuration(cur) {
+		return
+	}
+
+	var fixes []analysis.SuggestedFix
+	if len(operands) == 2 {
+		fixes = c.multiplicationFixes(cur, expr)
+	}
+
+	// the description of the suspect operands is appended to the translated message, keeping it the catalog key
+	format := c.config.translate("Multiplication of durations: `%s`") + "%s"
+	if c.reportRelatedf(RuleMul, expr, fixes, c.operandOrigins(units), format, c.formatExpr(expr), c.suspectOperands(operands, units)) {
+		c.setOperands(expr, found)
+		c.explain(expr, "multiplication of operands carrying a unit", units...)
+	}
+}
+
+// multiplicationOperands returns the operands of a chain of multiplications, e.g. a, b and time.Second for
+// `(a * b) * time.Second`
+func multiplicationOperands(expr ast.Expr) []ast.Expr {
+	if b, ok := ast.Unparen(expr).(*ast.BinaryExpr); ok && b.Op == token.MUL {
+		return append(multiplicationOperands(b.X), multiplicationOperands(b.Y)...)
+	}
+
+	return []ast.Expr{expr}
+}
+
+// operandProducts maps the operands of a chain of multiplications to the multiplication they are an operand of
+func operandProducts(expr ast.Expr) map[ast.Expr]*ast.BinaryExpr {
+	products := map[ast.Expr]*ast.BinaryExpr{}
+
+	var walk func(expr ast.Expr)
+	walk = func(expr ast.Expr) {
+		b, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+		if !ok || b.Op != token.MUL {
+			return
 		}
+
+		products[b.X], products[b.Y] = b, b
+		walk(b.X)
+		walk(b.Y)
 	}
+	walk(expr)
+
+	return products
 }
 
-func isDuration(x types.Type) bool {
-	return x.String() == "time.Duration" || x.String() == "*time.Duration"
+// classify classifies an operand of a multiplication, tracing its value with -dataflow unless it's a constant. The
+// reason is empty when the classifier decided, see debugOperands.
+func (c *checker) classify(product *ast.BinaryExpr, operand ast.Expr, tv types.TypeAndValue) (durationexpr.Kind, string) {
+	if c.isAcceptedName(operand) {
+		return durationexpr.Count, "named with a word of -accept-names"
+	}
+
+	if c.settings.dataflow && tv.Value == nil && product != nil {
+		if c.dataflow == nil {
+			c.dataflow = newDataflow(c.pass, c.classifier)
+		}
+
+		if kind, ok := c.dataflow.operandKind(product, operand); ok {
+			return kind, "value traced by -dataflow"
+		}
+	}
+
+	return c.classifier.Classify(operand), ""
 }
 
-// isUnacceptableExpr returns true if the argument is not an acceptable time.Duration expression
-func isUnacceptableExpr(pass *analysis.Pass, expr ast.Expr) bool {
-	switch e := expr.(type) {
-	case *ast.BasicLit:
+// isAcceptedName returns true if the operand is a variable or a field named with one of the words of -accept-names,
+// e.g. retryCount for count
+func (c *checker) isAcceptedName(operand ast.Expr) bool {
+	if len(c.acceptNames) == 0 {
 		return false
+	}
+
+	var ident *ast.Ident
+	switch e := ast.Unparen(operand).(type) {
 	case *ast.Ident:
-		return !isAcceptableNestedExpr(pass, e)
-	case *ast.CallExpr:
-		return !isAcceptableCast(pass, e)
-	case *ast.BinaryExpr:
-		return !isAcceptableNestedExpr(pass, e)
-	case *ast.UnaryExpr:
-		return !isAcceptableNestedExpr(pass, e)
+		ident = e
 	case *ast.SelectorExpr:
-		return !isAcceptableNestedExpr(pass, e)
-	case *ast.StarExpr:
-		return !isAcceptableNestedExpr(pass, e)
+		ident = e.Sel
 	default:
-		return true
+		return false
 	}
 
+	if _, ok := c.pass.TypesInfo.ObjectOf(ident).(*types.Var); !ok {
+		return false
+	}
+
+	for _, word := range splitWords(ident.Name) {
+		for _, accepted := range c.acceptNames {
+			if strings.EqualFold(word, accepted) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
-// isAcceptableCast returns true if the argument is an acceptable expression cast to time.Duration
-func isAcceptableCast(pass *analysis.Pass, e *ast.CallExpr) bool {
-	// check that there's a single argument
-	if len(e.Args) != 1 {
-		return false
+// isOutermostMultiplication returns false if the multiplication is an operand of another one, parenthesized or not
+func isOutermostMultiplication(cur inspector.Cursor) bool {
+	parent := cur.Parent()
+	for isParen(parent.Node()) {
+		parent = parent.Parent()
 	}
 
-	// check that the argument is acceptable
-	if !isAcceptableNestedExpr(pass, e.Args[0]) {
-		return false
+	outer, ok := parent.Node().(*ast.BinaryExpr)
+	return !ok || outer.Op != token.MUL
+}
+
+func isParen(node ast.Node) bool {
+	_, ok := node.(*ast.ParenExpr)
+	return ok
+}
+
+// checkMultiplicationAssignment reports compound assignments multiplying a duration by another one carrying a unit,
+// e.g. `timeout *= backoff`
+func (c *checker) checkMultiplicationAssignment(cur inspector.Cursor, stmt *ast.AssignStmt) {
+	if stmt.Tok != token.MUL_ASSIGN || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return
 	}
 
-	// check for time.Duration cast
-	selector, ok := e.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
+	x, xOK := c.pass.TypesInfo.Types[stmt.Lhs[0]]
+	y, yOK := c.pass.TypesInfo.Types[stmt.Rhs[0]]
+
+	if !xOK || !yOK {
+		c.incompletef(stmt, "the type of an operand is unknown")
+		return
 	}
 
-	return isDurationCast(selector)
+	if !c.classifier.IsDuration(x.Type) || !c.classifier.IsDuration(y.Type) {
+		return
+	}
+
+	if _, ok := c.redundantUnitOperand(stmt.Lhs[0], token.MUL, stmt.Rhs[0]); ok && c.enabled[RuleRedundantUnit] {
+		return
+	}
+
+	if parsed, _, _, ok := c.parsedRescale([]ast.Expr{stmt.Lhs[0], stmt.Rhs[0]}); ok && parsed == stmt.Lhs[0] && c.enabled[RuleParsedRescale] {
+		return
+	}
+
+	lhs, rhs := c.classifier.Classify(stmt.Lhs[0]), c.classifier.Classify(stmt.Rhs[0])
+	operands := []Operand{{Expr: stmt.Lhs[0], Type: x.Type, Kind: lhs}, {Expr: stmt.Rhs[0], Type: y.Type, Kind: rhs}}
+
+	if lhs == durationexpr.Unit && rhs == durationexpr.Unit {
+		if c.settings.usageContext && !c.storedAsDuration(cur, stmt.Lhs[0], map[types.Object]bool{}) {
+			return
+		}
+
+		if c.reportf(RuleMul, stmt, "Multiplication of durations in compound assignment: `%s`", c.formatExpr(stmt)) {
+			c.setOperands(stmt, operands)
+			product := &ast.BinaryExpr{X: stmt.Lhs[0], OpPos: stmt.TokPos, Op: token.MUL, Y: stmt.Rhs[0]}
+			c.explain(product, "multiplication of operands carrying a unit", stmt.Lhs[0], stmt.Rhs[0])
+		}
+	} else if c.settings.strict && !c.isUntypedConstant(stmt.Rhs[0]) {
+		if c.reportf(RuleMul, stmt, "Multiplication of durations in strict mode: `%s`", c.formatExpr(stmt)) {
+			c.setOperands(stmt, operands)
+		}
+	}
+}
+
+// reportf reports a diagnostic for the rule unless the rule is disabled for the file being checked or an effective
+// directive suppresses it, in which case it returns false. The message is translated by the catalog of the
+// configuration, if any.
+func (c *checker) reportf(rule string, node ast.Node, format string, args ...interface{}) bool {
+	return c.reportFixf(rule, node, nil, format, args...)
+}
+
+// reportFixf is like reportf and attaches the suggested fixes to the diagnostic
+func (c *checker) reportFixf(rule string, node ast.Node, fixes []analysis.SuggestedFix, format string, args ...interface{}) bool {
+	return c.reportRelatedf(rule, node, fixes, nil, format, args...)
 }
 
-func isDurationCast(selector *ast.SelectorExpr) bool {
-	pkg, ok := selector.X.(*ast.Ident)
-	if !ok {
+// reportRelatedf is like reportFixf and attaches the related information to the diagnostic
+func (c *checker) reportRelatedf(rule string, node ast.Node, fixes []analysis.SuggestedFix, related []analysis.RelatedInformation, format string, args ...interface{}) bool {
+	if !c.enabled[rule] || c.ignored(node) || c.excludedExpression(node) {
 		return false
 	}
 
-	if pkg.Name != "time" {
+	format = c.config.translate(format)
+
+	if d := c.suppression(node); d != nil {
+		if !d.expired() {
+			return false
+		}
+
+		format += c.config.translate(" (suppression expired on %s)")
+		args = append(args, d.until)
+	}
+
+	if c.settings.trainer != nil {
+		c.settings.trainer.record(c, rule, node)
 		return false
 	}
 
-	return selector.Sel.Name == "Duration"
+	diag, ok := c.report(analysis.Diagnostic{
+		Pos:            node.Pos(),
+		End:            node.End(),
+		Category:       rule,
+		Message:        fmt.Sprintf(format, args...),
+		SuggestedFixes: fixes,
+		Related:        related,
+	})
+	if ok {
+		c.addFinding(rule, node, diag.Message)
+	}
+
+	return true
 }
 
-func isAcceptableNestedExpr(pass *analysis.Pass, n ast.Expr) bool {
-	switch e := n.(type) {
-	case *ast.BasicLit:
-		return true
-	case *ast.BinaryExpr:
-		return isAcceptableNestedExpr(pass, e.X) && isAcceptableNestedExpr(pass, e.Y)
-	case *ast.UnaryExpr:
-		return isAcceptableNestedExpr(pass, e.X)
-	case *ast.Ident:
-		return isAcceptableIdent(pass, e)
-	case *ast.CallExpr:
-		t := pass.TypesInfo.TypeOf(e)
-		return !isDuration(t)
-	case *ast.SelectorExpr:
-		return isAcceptableNestedExpr(pass, e.X) && isAcceptableIdent(pass, e.Sel)
-	case *ast.StarExpr:
-		return isAcceptableNestedExpr(pass, e.X)
-	default:
-		return false
+// formatNode formats a node, falling back to the types.ExprString form of an expression the printer rejects. The
+// diagnostic messages use formatExpr instead, which reports the failure.
+func formatNode(node ast.Node) string {
+	s, err := printNode(node)
+	if expr, ok := node.(ast.Expr); ok && err != nil {
+		return types.ExprString(expr)
 	}
-}
 
-func isAcceptableIdent(pass *analysis.Pass, ident *ast.Ident) bool {
-	obj := pass.TypesInfo.ObjectOf(ident)
-	return !isDuration(obj.Type())
+	return s
 }
 
-func formatNode(node ast.Node) string {
+func printNode(node ast.Node) (string, error) {
 	buf := new(bytes.Buffer)
 	if err := format.Node(buf, token.NewFileSet(), node); err != nil {
-		log.Printf("Error formatting expression: %v", err)
-		return ""
+		return "", err
 	}
 
-	return buf.String()
+	return buf.String(), nil
 }
 
-func printAST(msg string, node ast.Node) {
-	fmt.Printf(">>> %s:\n%s\n\n\n", msg, formatNode(node))
-	ast.Fprint(os.Stdout, nil, node, nil)
-	fmt.Println("--------------")
+// formatExpr formats a node for a diagnostic message, truncating it to -max-expr-len characters. A node that can't be
+// formatted is quoted as written in the source instead.
+func (c *checker) formatExpr(node ast.Node) string {
+	s, err := printNode(node)
+	if err != nil {
+		c.incompletef(node, "the expression could not be formatted: %v", err)
+		s = c.sourceText(node)
+	}
+
+	return truncate(s, c.settings.maxExprLen)
+}
+
+// sourceText returns the source of a node, or its formatNode form if the source can't be read
+func (c *checker) sourceText(node ast.Node) string {
+	tf := c.pass.Fset.File(node.Pos())
+	if tf == nil || c.pass.ReadFile == nil || int(node.End()) > tf.Base()+tf.Size() {
+		return formatNode(node)
+	}
+
+	data, err := c.pass.ReadFile(tf.Name())
+	start, end := tf.Offset(node.Pos()), tf.Offset(node.End())
+	if err != nil || end > len(data) || start > end {
+		return formatNode(node)
+	}
+
+	return string(data[start:end])
+}
+
+// fail records an error of the analysis, returned by check once the package is analyzed
+func (c *checker) fail(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// truncate shortens s to at most limit characters, ending it with an ellipsis if it was cut
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if limit <= 0 || len(runes) <= limit {
+		return s
+	}
+
+	if limit <= 3 {
+		return string(runes[:limit])
+	}
+
+	return string(runes[:limit-3]) + "..."
 }
@@ -1,9 +1,17 @@
 package durationcheck_test
 
 import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charithe/durationcheck"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -11,3 +19,694 @@ func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, durationcheck.Analyzer, "a")
 }
+
+func TestStandalone(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.StandaloneAnalyzer, "a")
+}
+
+func TestIndirectDurations(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "indirect")
+}
+
+func TestMultiplicationChains(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "products")
+}
+
+func TestParenthesesAndSigns(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "parens")
+}
+
+func TestNestedConversions(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "nested")
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "compound")
+}
+
+func TestAliases(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "aliases")
+}
+
+func TestRenamedImports(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "renamed")
+}
+
+func TestDotImports(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "dotimport")
+}
+
+func TestMaxCountConst(t *testing.T) {
+	setFlag(t, "max-count-const", "100")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "countconst")
+}
+
+func TestDurationTypes(t *testing.T) {
+	setFlag(t, "duration-types", "github.com/prometheus/common/model.Duration")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "durationtypes")
+}
+
+func TestAcceptNames(t *testing.T) {
+	setFlag(t, "accept-names", "factor,multiplier,count,retries")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "acceptnames")
+}
+
+func TestDataflow(t *testing.T) {
+	setFlag(t, "dataflow", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "dataflow")
+}
+
+func TestTruncationIdiom(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "truncation")
+}
+
+func TestQuotients(t *testing.T) {
+	setFlag(t, "quotient", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "quotients")
+}
+
+func TestRedundantUnit(t *testing.T) {
+	setFlag(t, "redundant-unit", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "redundant")
+}
+
+func TestPrintf(t *testing.T) {
+	setFlag(t, "printf", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "printf")
+}
+
+func TestTaggedFields(t *testing.T) {
+	setFlag(t, "tagged-field", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "tags")
+}
+
+func TestUnitSuffix(t *testing.T) {
+	setFlag(t, "unit-suffix", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "suffixes")
+}
+
+func TestRawInt(t *testing.T) {
+	setFlag(t, "raw-int", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "rawint")
+}
+
+func TestParsedRescale(t *testing.T) {
+	setFlag(t, "parsed-rescale", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "parsed")
+}
+
+func TestStrict(t *testing.T) {
+	setFlag(t, "strict", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "strict")
+}
+
+func TestNames(t *testing.T) {
+	setFlag(t, "names", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "names")
+}
+
+// setFlag sets an analyzer flag for the duration of the test
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+
+	f := durationcheck.Analyzer.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("unknown flag %q", name)
+	}
+
+	old := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatalf("failed to set flag %q: %v", name, err)
+	}
+
+	t.Cleanup(func() { _ = f.Value.Set(old) })
+}
+
+func TestChecks(t *testing.T) {
+	setFlag(t, "checks", "bitwise")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "checks")
+}
+
+func TestBitwise(t *testing.T) {
+	setFlag(t, "bitwise", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "bitwise")
+}
+
+func TestReturnInt(t *testing.T) {
+	setFlag(t, "return-int", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "returns")
+}
+
+func TestIntParams(t *testing.T) {
+	setFlag(t, "int-params", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "params")
+}
+
+func TestFloatCount(t *testing.T) {
+	setFlag(t, "float-count", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "floats")
+}
+
+func TestIntDivision(t *testing.T) {
+	setFlag(t, "int-division", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "divisions")
+}
+
+func TestUnitChain(t *testing.T) {
+	setFlag(t, "unit-chain", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "chains")
+}
+
+func TestSentinel(t *testing.T) {
+	setFlag(t, "sentinel", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "sentinels")
+}
+
+func TestUnsigned(t *testing.T) {
+	setFlag(t, "unsigned", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "unsigned")
+}
+
+func TestIntAccumulator(t *testing.T) {
+	setFlag(t, "int-accumulator", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "accumulators")
+}
+
+func TestUnscaledAdd(t *testing.T) {
+	setFlag(t, "unscaled-add", "true")
+	setFlag(t, "unscaled-threshold", "10")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "unscaled")
+}
+
+func TestMultiplicationFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "mulfix")
+}
+
+func TestElapsedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "elapsedfix")
+}
+
+func TestBareInit(t *testing.T) {
+	setFlag(t, "bare-init", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "declarations")
+}
+
+func TestFlagDefault(t *testing.T) {
+	setFlag(t, "flag-default", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "flagdefaults")
+}
+
+func TestFlagDefaultPackages(t *testing.T) {
+	setFlag(t, "flag-default", "true")
+	setFlag(t, "flag-packages", "flag, example.com/flagkit")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "flagkit")
+}
+
+func TestUnitArgs(t *testing.T) {
+	setFlag(t, "unit-args", "true")
+	setFlag(t, "unit-apis", "unitargs.SetDeadline=s,unitargs.Client.Expire=m")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "unitargs")
+}
+
+func TestMiddleware(t *testing.T) {
+	setFlag(t, "bitwise", "true")
+
+	durationcheck.SetMiddleware(func(pass *analysis.Pass, diag analysis.Diagnostic) (analysis.Diagnostic, bool) {
+		if diag.Category == durationcheck.RuleBitwise {
+			return diag, false
+		}
+
+		diag.Message += " (see https://runbooks.example.com/" + diag.Category + ")"
+		return diag, true
+	})
+	t.Cleanup(func() { durationcheck.SetMiddleware(nil) })
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "middleware")
+}
+
+func TestMessageCatalog(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "messages.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "messages")
+}
+
+func TestNolint(t *testing.T) {
+	setFlag(t, "nolint", "respect")
+	setFlag(t, "config", filepath.Join("testdata", "config", "unused.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "nolint")
+}
+
+func TestNolintDirective(t *testing.T) {
+	setFlag(t, "nolint", "directive")
+	setFlag(t, "config", filepath.Join("testdata", "config", "unused.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "nolintdirective")
+}
+
+func TestDurationpb(t *testing.T) {
+	setFlag(t, "durationpb", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "protobuf")
+}
+
+func TestWrapperInit(t *testing.T) {
+	setFlag(t, "wrapper-init", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "kubernetes")
+}
+
+func TestCalendar(t *testing.T) {
+	setFlag(t, "calendar", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "calendar")
+}
+
+func TestClockField(t *testing.T) {
+	setFlag(t, "clock-field", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "clock")
+}
+
+func TestTimeoutCalls(t *testing.T) {
+	setFlag(t, "timeout-calls", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "timeouts")
+}
+
+func TestTimeoutCallsDurationTypes(t *testing.T) {
+	setFlag(t, "timeout-calls", "true")
+	setFlag(t, "duration-types", "github.com/prometheus/common/model.Duration")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "timeouttypes")
+}
+
+func TestMulCalls(t *testing.T) {
+	setFlag(t, "mul-calls", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "mulcalls")
+}
+
+func TestEpochDiff(t *testing.T) {
+	setFlag(t, "epoch-diff", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "epoch")
+}
+
+func TestGenerics(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "generics")
+}
+
+func TestResultFacts(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "helpers")
+}
+
+func TestZeroConst(t *testing.T) {
+	setFlag(t, "zero-const", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "constants")
+}
+
+func TestDoubleCast(t *testing.T) {
+	setFlag(t, "double-cast", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "doublecast")
+}
+
+func TestSleepLiteral(t *testing.T) {
+	setFlag(t, "sleep-literal", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "sleeps")
+}
+
+func TestCompareLiteral(t *testing.T) {
+	setFlag(t, "compare-literal", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "comparisons")
+}
+
+func TestOverflow(t *testing.T) {
+	setFlag(t, "overflow", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "overflow")
+}
+
+func TestRoundTrip(t *testing.T) {
+	setFlag(t, "round-trip", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.Analyzer, "roundtrip")
+}
+
+func TestConstFraction(t *testing.T) {
+	setFlag(t, "const-fraction", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "fractions")
+}
+
+func TestExemptions(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "exempt.yml"))
+	setFlag(t, "bitwise", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "exempt/...")
+}
+
+func TestIgnoreConfig(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "ignore.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "accepted")
+}
+
+func TestTestsRuleSet(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "tests.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "tests")
+}
+
+func TestSkipTests(t *testing.T) {
+	setFlag(t, "skip-tests", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "skiptests")
+}
+
+func TestSkipGeneratedFlag(t *testing.T) {
+	setFlag(t, "skip-generated", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "skipgenerated")
+}
+
+func TestConfigSettings(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "settings.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "configsettings")
+}
+
+func TestSkipGenerated(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "generated.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "generated")
+}
+
+func TestTestsOnly(t *testing.T) {
+	setFlag(t, "tests-only", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "testsonly")
+}
+
+func TestIgnoreDirectives(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "ignore")
+}
+
+func TestRequireReason(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "reason.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "reason")
+}
+
+func TestUnusedDirectives(t *testing.T) {
+	setFlag(t, "config", filepath.Join("testdata", "config", "unused.yml"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "unused")
+}
+
+func TestMaxExprLen(t *testing.T) {
+	setFlag(t, "max-expr-len", "42")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "long")
+}
+
+func TestFix(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, durationcheck.FixAnalyzer, "fix")
+}
+
+func TestDiagnosticRange(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, durationcheck.Analyzer, "products")
+
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			start, end := result.Pass.Fset.Position(diag.Pos), result.Pass.Fset.Position(diag.End)
+			if !diag.End.IsValid() || diag.End <= diag.Pos {
+				t.Errorf("%s: diagnostic %q ends at %s, want the end of the expression", start, diag.Message, end)
+			}
+		}
+	}
+}
+
+func TestRelatedInformation(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, durationcheck.Analyzer, "related")
+
+	want := [][]string{
+		{"14:2: `elapsed` is defined here as `time.Since(start)`"},
+		{"13:29: `timeout` is a parameter of type time.Duration", "6:2: `Backoff` is declared here with type time.Duration"},
+		{"9:6: `jitter` returns a duration carrying a unit", "13:29: `timeout` is a parameter of type time.Duration"},
+	}
+
+	var got [][]string
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			var related []string
+			for _, r := range diag.Related {
+				posn := result.Pass.Fset.Position(r.Pos)
+				related = append(related, fmt.Sprintf("%d:%d: %s", posn.Line, posn.Column, r.Message))
+			}
+			got = append(got, related)
+		}
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got related information\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestResult(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, durationcheck.Analyzer, "result")
+
+	want := []string{
+		"mul time.Duration(n) * timeout * time.Second: [time.Duration(n) time.Duration count] [timeout time.Duration unit] [time.Second time.Duration unit]",
+		"mul timeout *= time.Minute: [timeout time.Duration unit] [time.Minute time.Duration unit]",
+	}
+
+	var got []string
+	for _, result := range results {
+		for _, finding := range result.Result.(*durationcheck.Result).Findings {
+			var operands []string
+			for _, op := range finding.Operands {
+				operands = append(operands, fmt.Sprintf("[%s %s %s]", types.ExprString(op.Expr), op.Type, op.Kind))
+			}
+			got = append(got, fmt.Sprintf("%s %s: %s", finding.Rule, nodeString(result.Pass.Fset, finding.Node), strings.Join(operands, " ")))
+		}
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got findings\n%q\nwant\n%q", got, want)
+	}
+}
+
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	var b strings.Builder
+	if err := format.Node(&b, fset, node); err != nil {
+		return err.Error()
+	}
+
+	return b.String()
+}
+
+func TestCategories(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	for _, run := range []struct {
+		analyzer *analysis.Analyzer
+		pkg      string
+	}{
+		{durationcheck.Analyzer, "a"},
+		{durationcheck.FixAnalyzer, "fix"},
+	} {
+		for _, result := range analysistest.Run(t, testdata, run.analyzer, run.pkg) {
+			for _, diag := range result.Diagnostics {
+				if diag.Category == "" {
+					t.Errorf("%s: diagnostic %q of %s has no category", result.Pass.Fset.Position(diag.Pos), diag.Message, run.analyzer.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestSkipPaths(t *testing.T) {
+	setFlag(t, "skip-dirs", "third_party")
+	setFlag(t, "skip-files", "*_gen.go")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "skipped/...")
+	analysistest.Run(t, testdata, durationcheck.NewAnalyzer(durationcheck.WithSkipDirs("skipped/third_party/"), durationcheck.WithExcludedPaths("*_gen.go")), "skipped/...")
+}
+
+func TestNewAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.NewAnalyzer(durationcheck.WithRules(durationcheck.RuleBitwise)), "bitwise")
+	analysistest.Run(t, testdata, durationcheck.NewStandaloneAnalyzer(durationcheck.WithExcludedPaths("**/*_fake.go")), "excluded")
+
+	// the options of an analyzer don't leak into the package-level ones
+	if f := durationcheck.Analyzer.Flags.Lookup("bitwise"); f.Value.String() != "false" {
+		t.Errorf("-bitwise of Analyzer = %s, want false", f.Value)
+	}
+}
+
+func TestExcludeExpr(t *testing.T) {
+	analyzer := durationcheck.NewAnalyzer(durationcheck.WithExcludedExprs(`jitter \* spread@^Retrier\.`, `^d \* d$`, `^timeout \* interval$`))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "excludeexpr")
+}
+
+func TestExcludeExprInvalid(t *testing.T) {
+	var errors recorder
+	results := analysistest.Run(&errors, analysistest.TestData(), durationcheck.NewAnalyzer(durationcheck.WithExcludedExprs("d * d@(")), "excludeexpr")
+
+	if len(results) != 1 || results[0].Err == nil || !strings.HasPrefix(results[0].Err.Error(), `invalid -exclude-expr "d * d@("`) {
+		t.Errorf("got results %v, want the invalid pattern error", results)
+	}
+}
+
+func TestNewAnalyzerInvalidOption(t *testing.T) {
+	var errors recorder
+	results := analysistest.Run(&errors, analysistest.TestData(), durationcheck.NewAnalyzer(durationcheck.WithRules("nope")), "a")
+
+	if len(results) != 1 || results[0].Err == nil || results[0].Err.Error() != `unknown rule "nope"` {
+		t.Errorf("got results %v, want the unknown rule error", results)
+	}
+}
+
+// recorder implements analysistest.Testing, recording the errors instead of failing the test
+type recorder []string
+
+func (r *recorder) Errorf(format string, args ...any) {
+	*r = append(*r, fmt.Sprintf(format, args...))
+}
+
+func TestMinConfidence(t *testing.T) {
+	setFlag(t, "checks", "all")
+	setFlag(t, "min-confidence", "certain")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "confidence")
+}
+
+func TestUsageContext(t *testing.T) {
+	setFlag(t, "usage-context", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "usage")
+}
+
+func TestTypeErrors(t *testing.T) {
+	setFlag(t, "checks", "all")
+	durationcheck.Analyzer.RunDespiteErrors = true
+	t.Cleanup(func() { durationcheck.Analyzer.RunDespiteErrors = false })
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationcheck.Analyzer, "typeerrors")
+}
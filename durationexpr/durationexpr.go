@@ -0,0 +1,435 @@
+// Package durationexpr classifies expressions of type time.Duration.
+//
+// It holds the semantics shared by durationcheck and related analyzers: whether an operand of duration arithmetic is
+// a dimensionless count (a literal, or a conversion of a plain number) or a value that already carries a unit of time.
+package durationexpr
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Kind is the classification of an expression of type time.Duration.
+type Kind int
+
+const (
+	// Count is a dimensionless value, e.g. `10` or `time.Duration(n)` where n is a plain number.
+	Count Kind = iota
+	// Unit is a value that already carries a unit of time, e.g. `time.Second` or a time.Duration variable.
+	Unit
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Count:
+		return "count"
+	case Unit:
+		return "unit"
+	default:
+		return "unknown"
+	}
+}
+
+// Classifier classifies duration expressions using the type information of a package.
+type Classifier struct {
+	Info *types.Info
+	// MaxCountConst classifies the constants of type time.Duration declared outside the time package whose absolute
+	// value is at most MaxCountConst as counts, e.g. `const retries time.Duration = 3`. Zero disables it.
+	MaxCountConst int64
+	// Results holds the kind of the durations returned by functions, e.g. Count for a helper returning
+	// `time.Duration(n)`, classifying the calls to them. The calls to the other functions returning durations are Unit.
+	Results map[*types.Func]Kind
+	// Types are additional duration types with the semantics of time.Duration, identified by their package path and
+	// their name, e.g. `github.com/prometheus/common/model.Duration`.
+	Types []string
+
+	// extraTypes and typeParams cache the types resolved against Types and the type sets of the type parameters,
+	// so a Classifier must not be used concurrently nor have its fields changed once in use
+	extraTypes map[*types.TypeName]bool
+	typeParams map[*types.TypeParam]terms
+}
+
+// terms tells whether all the types of a type set are durations, and whether some of them are
+type terms struct {
+	all, some bool
+}
+
+// IsDuration returns true if the type is time.Duration or a pointer to it, directly or through type aliases.
+//
+// The type is identified by its declaring object rather than by its printed name, which doesn't allocate and doesn't
+// depend on how the type is qualified.
+func IsDuration(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	if p, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && isTimeDuration(named.Obj())
+}
+
+// IsDuration is like the IsDuration function but also returns true for the additional Types, and for the type
+// parameters whose type set only holds durations, e.g. T in `[T time.Duration | model.Duration]`.
+func (c *Classifier) IsDuration(t types.Type) bool {
+	if IsDuration(t) {
+		return true
+	}
+
+	if tp, ok := types.Unalias(t).(*types.TypeParam); ok {
+		all, _ := c.durationTerms(tp)
+		return all
+	}
+
+	if t == nil || len(c.Types) == 0 {
+		return false
+	}
+
+	if p, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && c.isExtraType(named.Obj())
+}
+
+// mayBeDuration returns true if the type is a type parameter whose type set holds a duration, e.g. T in
+// `[T ~int64 | time.Duration]`. Approximation elements like ~int64 don't count, although time.Duration satisfies
+// them, since they are the usual constraints of generic numeric code.
+func (c *Classifier) mayBeDuration(t types.Type) bool {
+	tp, ok := types.Unalias(t).(*types.TypeParam)
+	if !ok {
+		return false
+	}
+
+	_, some := c.durationTerms(tp)
+	return some
+}
+
+// durationTerms returns whether all the types of the type set of the type parameter are durations, and whether some
+// of them are. The type set is the intersection of the elements of the constraint, each the union of its terms.
+func (c *Classifier) durationTerms(tp *types.TypeParam) (all, some bool) {
+	if t, ok := c.typeParams[tp]; ok {
+		return t.all, t.some
+	}
+
+	if iface, ok := tp.Constraint().Underlying().(*types.Interface); ok {
+		all, some = c.elementTerms(iface, map[*types.Interface]bool{})
+	}
+
+	if c.typeParams == nil {
+		c.typeParams = map[*types.TypeParam]terms{}
+	}
+	c.typeParams[tp] = terms{all, some}
+
+	return all, some
+}
+
+func (c *Classifier) elementTerms(iface *types.Interface, visited map[*types.Interface]bool) (all, some bool) {
+	if visited[iface] || iface.NumEmbeddeds() == 0 {
+		return false, false
+	}
+	visited[iface] = true
+
+	some = true
+	for i := range iface.NumEmbeddeds() {
+		var elemAll, elemSome bool
+		switch e := iface.EmbeddedType(i).(type) {
+		case *types.Union:
+			elemAll = e.Len() > 0
+			for j := range e.Len() {
+				isDuration := !e.Term(j).Tilde() && c.IsDuration(e.Term(j).Type())
+				elemAll = elemAll && isDuration
+				elemSome = elemSome || isDuration
+			}
+		default:
+			if embedded, ok := e.Underlying().(*types.Interface); ok {
+				// interfaces without terms, e.g. comparable, don't restrict the type set to numbers
+				if embedded.NumEmbeddeds() == 0 {
+					continue
+				}
+				elemAll, elemSome = c.elementTerms(embedded, visited)
+			} else {
+				elemAll = c.IsDuration(e)
+				elemSome = elemAll
+			}
+		}
+
+		all = all || elemAll
+		some = some && elemSome
+	}
+
+	return all, some
+}
+
+// isDurationType returns true if the type name is time.Duration or one of the additional Types
+func (c *Classifier) isDurationType(obj *types.TypeName) bool {
+	return isTimeDuration(obj) || c.isExtraType(obj)
+}
+
+func (c *Classifier) isExtraType(obj *types.TypeName) bool {
+	if obj.Pkg() == nil || len(c.Types) == 0 {
+		return false
+	}
+
+	if extra, ok := c.extraTypes[obj]; ok {
+		return extra
+	}
+
+	extra := false
+	for _, name := range c.Types {
+		if i := strings.LastIndex(name, "."); i > 0 && name[:i] == obj.Pkg().Path() && name[i+1:] == obj.Name() {
+			extra = true
+			break
+		}
+	}
+
+	if c.extraTypes == nil {
+		c.extraTypes = map[*types.TypeName]bool{}
+	}
+	c.extraTypes[obj] = extra
+
+	return extra
+}
+
+// Classify returns the kind of a duration-typed expression.
+func (c *Classifier) Classify(expr ast.Expr) Kind {
+	if c.isUnacceptableExpr(expr) {
+		return Unit
+	}
+
+	return Count
+}
+
+// IsConversion returns true if the call is a conversion of a single argument to time.Duration, or to an alias of it.
+func (c *Classifier) IsConversion(call *ast.CallExpr) bool {
+	if len(call.Args) != 1 {
+		return false
+	}
+
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		if c.isDurationCast(fun) {
+			return true
+		}
+	case *ast.Ident:
+		// `Duration(n)` with the time package dot-imported
+		if obj, ok := c.Info.Uses[fun].(*types.TypeName); ok && c.isDurationType(obj) {
+			return true
+		}
+	}
+
+	// `model.Duration(n)` for the additional Types
+	if fun, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if obj, ok := c.Info.Uses[fun.Sel].(*types.TypeName); ok && c.isExtraType(obj) {
+			return true
+		}
+	}
+
+	return c.isDurationAlias(call.Fun)
+}
+
+// isDurationAlias returns true if the expression names an alias of time.Duration, e.g. `Timeout` declared as
+// `type Timeout = time.Duration`
+func (c *Classifier) isDurationAlias(expr ast.Expr) bool {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return false
+	}
+
+	obj, ok := c.Info.ObjectOf(ident).(*types.TypeName)
+	if !ok || !obj.IsAlias() {
+		return false
+	}
+
+	_, isPointer := types.Unalias(obj.Type()).(*types.Pointer)
+	return !isPointer && c.IsDuration(obj.Type())
+}
+
+// isUnacceptableExpr returns true if the argument is not an acceptable time.Duration expression
+func (c *Classifier) isUnacceptableExpr(expr ast.Expr) bool {
+	switch e := unwrap(expr).(type) {
+	case *ast.BasicLit:
+		return false
+	case *ast.Ident:
+		return !c.isAcceptableNestedExpr(e)
+	case *ast.CallExpr:
+		return !c.isAcceptableCast(e) && !c.isCountCall(e)
+	case *ast.BinaryExpr:
+		return !c.isAcceptableNestedExpr(e)
+	case *ast.UnaryExpr:
+		return !c.isAcceptableNestedExpr(e)
+	case *ast.SelectorExpr:
+		return !c.isAcceptableNestedExpr(e)
+	case *ast.StarExpr:
+		return !c.isAcceptableNestedExpr(e)
+	default:
+		return true
+	}
+}
+
+// isAcceptableCast returns true if the argument is an acceptable expression cast to time.Duration
+func (c *Classifier) isAcceptableCast(e *ast.CallExpr) bool {
+	// check that there's a single argument
+	if len(e.Args) != 1 {
+		return false
+	}
+
+	// check that the argument is acceptable
+	if !c.isAcceptableNestedExpr(e.Args[0]) {
+		return false
+	}
+
+	return c.IsConversion(e)
+}
+
+// isDurationCast returns true if the selector is time.Duration, whatever the name the time package is imported with
+func (c *Classifier) isDurationCast(selector *ast.SelectorExpr) bool {
+	pkg, ok := selector.X.(*ast.Ident)
+	if !ok || selector.Sel.Name != "Duration" {
+		return false
+	}
+
+	if name, ok := c.Info.Uses[pkg].(*types.PkgName); ok {
+		return name.Imported().Path() == "time"
+	}
+
+	// without type information, rely on the usual import name
+	return pkg.Name == "time"
+}
+
+// isCountCall returns true if the call is to a function returning counts, see Results
+func (c *Classifier) isCountCall(call *ast.CallExpr) bool {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return false
+	}
+
+	kind, ok := c.Results[fn]
+	return ok && kind == Count
+}
+
+// calledFunc returns the function or method called statically, nil for conversions and dynamic calls
+func (c *Classifier) calledFunc(call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.IndexExpr:
+		// instantiation of a generic function, e.g. `Count[int](n)`
+		return c.calledFunc(&ast.CallExpr{Fun: fun.X})
+	case *ast.IndexListExpr:
+		return c.calledFunc(&ast.CallExpr{Fun: fun.X})
+	default:
+		return nil
+	}
+
+	fn, ok := c.Info.Uses[ident].(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	return fn.Origin()
+}
+
+// numericConversion returns the operand of a conversion to a numeric type, e.g. d for `int64(d)` or `float64(d)`
+func (c *Classifier) numericConversion(call *ast.CallExpr) (ast.Expr, bool) {
+	if len(call.Args) != 1 {
+		return nil, false
+	}
+
+	tv, ok := c.Info.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return nil, false
+	}
+
+	basic, ok := tv.Type.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsNumeric == 0 {
+		return nil, false
+	}
+
+	return call.Args[0], true
+}
+
+// unwrap strips the parentheses and signs around an expression, which don't change whether it carries a unit
+func unwrap(expr ast.Expr) ast.Expr {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.UnaryExpr:
+			if e.Op != token.SUB && e.Op != token.ADD {
+				return expr
+			}
+			expr = e.X
+		default:
+			return expr
+		}
+	}
+}
+
+func isTimeDuration(obj *types.TypeName) bool {
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration"
+}
+
+func (c *Classifier) isAcceptableNestedExpr(n ast.Expr) bool {
+	switch e := n.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.ParenExpr:
+		return c.isAcceptableNestedExpr(e.X)
+	case *ast.BinaryExpr:
+		return c.isAcceptableNestedExpr(e.X) && c.isAcceptableNestedExpr(e.Y)
+	case *ast.UnaryExpr:
+		return c.isAcceptableNestedExpr(e.X)
+	case *ast.Ident:
+		return c.isAcceptableIdent(e)
+	case *ast.CallExpr:
+		// numeric conversions keep the unit of their operand, e.g. `int64(d)`
+		if arg, ok := c.numericConversion(e); ok {
+			return c.isAcceptableNestedExpr(arg)
+		}
+
+		t := c.Info.TypeOf(e)
+		return !c.IsDuration(t) || c.isCountCall(e)
+	case *ast.SelectorExpr:
+		return c.isAcceptableNestedExpr(e.X) && c.isAcceptableIdent(e.Sel)
+	case *ast.StarExpr:
+		return c.isAcceptableNestedExpr(e.X)
+	default:
+		return false
+	}
+}
+
+func (c *Classifier) isAcceptableIdent(ident *ast.Ident) bool {
+	obj := c.Info.ObjectOf(ident)
+	if obj == nil {
+		// undefined in a package with type errors, nothing tells it carries a unit
+		return true
+	}
+
+	return !c.IsDuration(obj.Type()) && !c.mayBeDuration(obj.Type()) || c.isCountConst(obj)
+}
+
+// isCountConst returns true if the object is a duration constant small enough to be a count, see MaxCountConst
+func (c *Classifier) isCountConst(obj types.Object) bool {
+	k, ok := obj.(*types.Const)
+	if !ok || c.MaxCountConst <= 0 || k.Pkg() == nil || k.Pkg().Path() == "time" {
+		return false
+	}
+
+	n, exact := constant.Int64Val(constant.ToInt(k.Val()))
+	return exact && n <= c.MaxCountConst && n >= -c.MaxCountConst
+}
@@ -0,0 +1,207 @@
+package durationexpr_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/charithe/durationcheck/durationexpr"
+)
+
+const src = `package p
+
+import "time"
+
+type config struct {
+	timeout time.Duration
+	retries int
+}
+
+func f(d time.Duration, n int, cfg config) {
+	_ = 10
+	_ = time.Duration(n)
+	_ = time.Duration(cfg.retries)
+	_ = time.Duration(10 + n*2)
+	_ = -1
+	_ = time.Second
+	_ = d
+	_ = cfg.timeout
+	_ = time.Duration(d)
+	_ = f2()
+	_ = (time.Second)
+	_ = timeout(n)
+	_ = timeout(d)
+	_ = (time.Duration(n))
+	_ = -time.Duration(n)
+	_ = -d
+	_ = time.Duration((n))
+	_ = time.Duration(int64(d))
+	_ = time.Duration(float64(int64(d)) * 1.5)
+	_ = time.Duration(int64(n))
+}
+
+type timeout = time.Duration
+
+func f2() time.Duration { return 0 }
+`
+
+func TestClassify(t *testing.T) {
+	want := []durationexpr.Kind{
+		durationexpr.Count,
+		durationexpr.Count,
+		durationexpr.Count,
+		durationexpr.Count,
+		durationexpr.Count,
+		durationexpr.Unit,
+		durationexpr.Unit,
+		durationexpr.Unit,
+		durationexpr.Unit,
+		durationexpr.Unit,
+		durationexpr.Unit,
+		durationexpr.Count,
+		durationexpr.Unit,
+		durationexpr.Count,
+		durationexpr.Count,
+		durationexpr.Unit,
+		durationexpr.Count,
+		durationexpr.Unit,
+		durationexpr.Unit,
+		durationexpr.Count,
+	}
+
+	classifier, exprs := load(t, src)
+	if len(exprs) != len(want) {
+		t.Fatalf("found %d expressions, want %d", len(exprs), len(want))
+	}
+
+	for i, expr := range exprs {
+		if got := classifier.Classify(expr); got != want[i] {
+			t.Errorf("expression %d: got %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestIsConversion(t *testing.T) {
+	classifier, exprs := load(t, src)
+
+	var conversions int
+	for _, expr := range exprs {
+		if call, ok := expr.(*ast.CallExpr); ok && classifier.IsConversion(call) {
+			conversions++
+		}
+	}
+
+	if conversions != 10 {
+		t.Errorf("found %d conversions, want 10", conversions)
+	}
+}
+
+// load type checks the source and returns the right hand side of every blank assignment in it
+func load(t testing.TB, src string) (*durationexpr.Classifier, []ast.Expr) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	var exprs []ast.Expr
+	ast.Inspect(file, func(node ast.Node) bool {
+		if assign, ok := node.(*ast.AssignStmt); ok {
+			exprs = append(exprs, assign.Rhs[0])
+		}
+		return true
+	})
+
+	return &durationexpr.Classifier{Info: info}, exprs
+}
+
+func TestExplain(t *testing.T) {
+	classifier, exprs := load(t, src)
+
+	for i, expr := range exprs {
+		trace := classifier.Explain(expr)
+		if trace.Kind != classifier.Classify(expr) {
+			t.Errorf("expression %d: explained as %s, classified as %s", i, trace.Kind, classifier.Classify(expr))
+		}
+		if trace.Reason == "" {
+			t.Errorf("expression %d: no reason", i)
+		}
+	}
+
+	// `time.Duration(cfg.retries)`
+	trace := classifier.Explain(exprs[2])
+	if trace.Reason != "conversion of a plain number" || len(trace.Operands) != 1 {
+		t.Fatalf("got %+v", trace)
+	}
+	if sel := trace.Operands[0]; len(sel.Operands) != 2 || sel.Operands[1].Reason != "variable of type int" {
+		t.Errorf("got %+v", sel)
+	}
+}
+
+// largeSource returns a package declaring n functions mixing duration and integer arithmetic
+func largeSource(n int) string {
+	var b strings.Builder
+	b.WriteString("package p\n\nimport \"time\"\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `func f%d(d time.Duration, n int, p *time.Duration) {
+	_ = time.Duration(n) * time.Second
+	_ = d * time.Duration(n+%d)
+	_ = n * %d
+	_ = *p + d
+	_ = float64(n) / 2
+}
+
+`, i, i, i)
+	}
+
+	return b.String()
+}
+
+func BenchmarkIsDuration(b *testing.B) {
+	classifier, _ := load(b, largeSource(1000))
+
+	typs := make([]types.Type, 0, len(classifier.Info.Types))
+	for _, tv := range classifier.Info.Types {
+		typs = append(typs, tv.Type)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, t := range typs {
+			durationexpr.IsDuration(t)
+		}
+	}
+}
+
+func BenchmarkClassify(b *testing.B) {
+	classifier, exprs := load(b, largeSource(1000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, expr := range exprs {
+			classifier.Classify(expr)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package durationexpr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// Trace explains the classification of an expression: its kind, the reason for it and the traces of the operands the
+// kind was derived from.
+type Trace struct {
+	Expr     ast.Expr
+	Kind     Kind
+	Reason   string
+	Operands []*Trace
+}
+
+// Explain classifies a duration-typed expression like Classify does and returns the evidence for it.
+func (c *Classifier) Explain(expr ast.Expr) *Trace {
+	// parentheses and signs are explained by the operand they wrap
+	if inner := unwrap(expr); inner != expr {
+		trace := c.Explain(inner)
+		trace.Expr = expr
+		return trace
+	}
+
+	switch e := expr.(type) {
+	case *ast.BasicLit, *ast.Ident, *ast.BinaryExpr, *ast.UnaryExpr, *ast.SelectorExpr, *ast.StarExpr:
+		return c.explainNested(e)
+	case *ast.CallExpr:
+		if !c.IsConversion(e) {
+			if c.isCountCall(e) {
+				return &Trace{Expr: e, Kind: Count, Reason: "call returning a count"}
+			}
+			return &Trace{Expr: e, Kind: Unit, Reason: "call returning a duration"}
+		}
+
+		arg := c.explainNested(e.Args[0])
+		if arg.Kind == Unit {
+			return &Trace{Expr: e, Kind: Unit, Reason: "conversion of a value carrying a unit", Operands: []*Trace{arg}}
+		}
+		return &Trace{Expr: e, Kind: Count, Reason: "conversion of a plain number", Operands: []*Trace{arg}}
+	default:
+		return &Trace{Expr: e, Kind: Unit, Reason: fmt.Sprintf("unsupported %T expression", e)}
+	}
+}
+
+// explainNested mirrors isAcceptableNestedExpr
+func (c *Classifier) explainNested(expr ast.Expr) *Trace {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return &Trace{Expr: e, Kind: Count, Reason: "literal"}
+	case *ast.BinaryExpr:
+		return combine(e, c.explainNested(e.X), c.explainNested(e.Y))
+	case *ast.UnaryExpr:
+		return combine(e, c.explainNested(e.X))
+	case *ast.ParenExpr:
+		return combine(e, c.explainNested(e.X))
+	case *ast.StarExpr:
+		return combine(e, c.explainNested(e.X))
+	case *ast.SelectorExpr:
+		// qualified identifiers, e.g. `time.Second`, are explained by their object
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			if _, ok := c.Info.ObjectOf(pkg).(*types.PkgName); ok {
+				trace := c.explainIdent(e.Sel)
+				trace.Expr = e
+				return trace
+			}
+		}
+		return combine(e, c.explainNested(e.X), c.explainIdent(e.Sel))
+	case *ast.Ident:
+		return c.explainIdent(e)
+	case *ast.CallExpr:
+		if arg, ok := c.numericConversion(e); ok {
+			trace := c.explainNested(arg)
+			if trace.Kind == Unit {
+				return &Trace{Expr: e, Kind: Unit, Reason: "numeric conversion of a value carrying a unit", Operands: []*Trace{trace}}
+			}
+			return &Trace{Expr: e, Kind: Count, Reason: "numeric conversion of a plain number", Operands: []*Trace{trace}}
+		}
+
+		if t := c.Info.TypeOf(e); c.IsDuration(t) {
+			if c.isCountCall(e) {
+				return &Trace{Expr: e, Kind: Count, Reason: "call returning a count"}
+			}
+			return &Trace{Expr: e, Kind: Unit, Reason: "call returning a duration"}
+		}
+		return &Trace{Expr: e, Kind: Count, Reason: "call returning a plain number"}
+	default:
+		return &Trace{Expr: e, Kind: Unit, Reason: fmt.Sprintf("unsupported %T expression", e)}
+	}
+}
+
+func (c *Classifier) explainIdent(ident *ast.Ident) *Trace {
+	obj := c.Info.ObjectOf(ident)
+	if obj == nil {
+		return &Trace{Expr: ident, Kind: Count, Reason: "undefined identifier"}
+	}
+
+	if c.isCountConst(obj) {
+		return &Trace{Expr: ident, Kind: Count, Reason: fmt.Sprintf("constant of type %s with the small value %s", obj.Type(), obj.(*types.Const).Val())}
+	}
+
+	kind := Count
+	if c.IsDuration(obj.Type()) || c.mayBeDuration(obj.Type()) {
+		kind = Unit
+	}
+
+	return &Trace{Expr: ident, Kind: kind, Reason: fmt.Sprintf("%s of type %s", objectKind(obj), obj.Type())}
+}
+
+// combine derives the trace of an expression from those of its operands: it carries a unit if any of them does
+func combine(expr ast.Expr, operands ...*Trace) *Trace {
+	for _, op := range operands {
+		if op.Kind == Unit {
+			return &Trace{Expr: expr, Kind: Unit, Reason: "operand carrying a unit", Operands: operands}
+		}
+	}
+
+	return &Trace{Expr: expr, Kind: Count, Reason: "operands are plain numbers", Operands: operands}
+}
+
+func objectKind(obj types.Object) string {
+	switch obj.(type) {
+	case *types.Const:
+		return "constant"
+	case *types.Var:
+		return "variable"
+	case *types.Func:
+		return "function"
+	default:
+		return "identifier"
+	}
+}
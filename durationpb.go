@@ -0,0 +1,73 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+)
+
+const durationpbPath = "google.golang.org/protobuf/types/known/durationpb"
+
+// checkDurationpb reports conversions to time.Duration computed from the Seconds and Nanos fields of a
+// durationpb.Duration, which its AsDuration method does with overflow handling, and counts passed to durationpb.New,
+// which are nanoseconds
+func (c *checker) checkDurationpb(call *ast.CallExpr) {
+	if c.classifier.IsConversion(call) {
+		if msg := c.durationpbField(call.Args[0]); msg != nil {
+			c.reportf(RuleDurationpb, call, "Manual conversion of durationpb fields to duration: use `%s.AsDuration()`", c.formatExpr(msg))
+		}
+		return
+	}
+
+	fn := c.calledFunc(call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != durationpbPath || fn.Name() != "New" || len(call.Args) != 1 {
+		return
+	}
+
+	arg := call.Args[0]
+	if tv, ok := c.pass.TypesInfo.Types[arg]; ok && tv.Value != nil && tv.Value.String() == "0" {
+		return
+	}
+
+	if c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(arg)) && c.classifier.Classify(arg) == durationexpr.Count {
+		c.reportf(RuleDurationpb, arg, "Count `%s` passed to durationpb.New is in nanoseconds: multiply it by a unit", c.formatExpr(arg))
+	}
+}
+
+// durationpbField returns the durationpb.Duration message whose Seconds or Nanos fields, or their getters, the
+// expression uses, or nil
+func (c *checker) durationpbField(expr ast.Expr) ast.Expr {
+	var msg ast.Expr
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if msg != nil {
+			return false
+		}
+
+		selector, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch selector.Sel.Name {
+		case "Seconds", "Nanos", "GetSeconds", "GetNanos":
+			if isDurationpb(c.pass.TypesInfo.TypeOf(selector.X)) {
+				msg = selector.X
+			}
+		}
+
+		return true
+	})
+
+	return msg
+}
+
+// isDurationpb reports whether the type is durationpb.Duration or a pointer to it
+func isDurationpb(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == durationpbPath && named.Obj().Name() == "Duration"
+}
@@ -0,0 +1,98 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// epochAccessors are the time.Time methods returning the time since the epoch, and its unit
+var epochAccessors = map[string]string{
+	"Unix":      "Second",
+	"UnixMilli": "Millisecond",
+	"UnixMicro": "Microsecond",
+	"UnixNano":  "Nanosecond",
+}
+
+// checkEpochDifference reports differences of Unix timestamps converted to durations without being scaled by their
+// unit, e.g. `time.Duration(t2.Unix() - t1.Unix())` or `time.Duration(time.Now().UnixMilli() - start)`, which are
+// counts of seconds or milliseconds taken for nanoseconds. Products scaled by a unit aren't reported.
+func (c *checker) checkEpochDifference(cur inspector.Cursor, call *ast.CallExpr) {
+	if !c.classifier.IsConversion(call) {
+		return
+	}
+
+	diff, ok := ast.Unparen(call.Args[0]).(*ast.BinaryExpr)
+	if !ok || diff.Op != token.SUB {
+		return
+	}
+
+	x, xUnit := c.epochAccessor(diff.X)
+	y, yUnit := c.epochAccessor(diff.Y)
+	unit := xUnit
+	if unit == "" {
+		unit = yUnit
+	}
+
+	// differences of nanoseconds are durations, and those mixing units are another bug
+	if unit == "" || unit == "Nanosecond" || x != nil && y != nil && xUnit != yUnit || !isInteger(c.pass.TypesInfo.TypeOf(diff)) {
+		return
+	}
+
+	for _, operand := range multiplicationOperands(enclosingProduct(cur)) {
+		if isUnitConstant(c.pass, operand) {
+			return
+		}
+	}
+
+	qualifier, ok := c.timeQualifier()
+	if !ok {
+		qualifier = "time"
+	}
+
+	fixes := c.unitSuffixFixes(call, cur.Parent().Node(), unit)
+	advice := "multiply it by " + qualifier + "." + unit
+	if x != nil && y != nil {
+		sub := formatOperand(x) + ".Sub(" + formatNode(y) + ")"
+		advice = "use `" + sub + "` or " + advice
+		if _, ok := c.timeQualifier(); ok {
+			fixes = append(c.replacementFix("Use "+sub, call, sub), fixes...)
+		}
+	}
+
+	c.reportFixf(RuleEpochDiff, call, fixes, "Difference of Unix timestamps `%s` in %ss converted to duration without a unit: %s",
+		c.formatExpr(diff), strings.ToLower(unit), advice)
+}
+
+// epochAccessor returns the time of a call to a time.Time method returning the time since the epoch, e.g. t for
+// `t.Unix()`, and the unit of the method, nil and "" for other expressions
+func (c *checker) epochAccessor(expr ast.Expr) (ast.Expr, string) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, ""
+	}
+
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, ""
+	}
+
+	fn := c.calledFunc(call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "time" {
+		return nil, ""
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil || !isTimeType(recv.Type()) {
+		return nil, ""
+	}
+
+	unit, ok := epochAccessors[fn.Name()]
+	if !ok {
+		return nil, ""
+	}
+
+	return sel.X, unit
+}
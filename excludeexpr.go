@@ -0,0 +1,87 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// excludedExpr is a pattern of -exclude-expr: a regular expression matched against the reported expressions, and
+// optionally one matched against the name of their enclosing function
+type excludedExpr struct {
+	expr *regexp.Regexp
+	fn   *regexp.Regexp
+}
+
+// parseExcludedExprs compiles the -exclude-expr patterns, `expr` or `expr@func`, e.g. `jitter \* spread@^Backoff$`
+func parseExcludedExprs(patterns []string) ([]excludedExpr, error) {
+	excluded := make([]excludedExpr, 0, len(patterns))
+	for _, pattern := range patterns {
+		exprPattern, fnPattern, scoped := cutLast(pattern, "@")
+
+		var e excludedExpr
+		var err error
+		if e.expr, err = regexp.Compile(exprPattern); err != nil {
+			return nil, fmt.Errorf("invalid -exclude-expr %q: %w", pattern, err)
+		}
+
+		if scoped {
+			if e.fn, err = regexp.Compile(fnPattern); err != nil {
+				return nil, fmt.Errorf("invalid -exclude-expr %q: %w", pattern, err)
+			}
+		}
+
+		excluded = append(excluded, e)
+	}
+
+	return excluded, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+
+	return s, "", false
+}
+
+// excludedExpression returns true if the reported node, formatted with gofmt, matches a pattern of -exclude-expr
+func (c *checker) excludedExpression(node ast.Node) bool {
+	if len(c.excludedExprs) == 0 {
+		return false
+	}
+
+	s, err := printNode(node)
+	if err != nil {
+		return false
+	}
+
+	fn, inFunc := c.enclosingFuncName(node.Pos())
+	for _, e := range c.excludedExprs {
+		if e.expr.MatchString(s) && (e.fn == nil || inFunc && e.fn.MatchString(fn)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enclosingFuncName returns the name of the function declaration of the file being checked containing pos, e.g.
+// `Client.Do` for methods
+func (c *checker) enclosingFuncName(pos token.Pos) (string, bool) {
+	for _, decl := range c.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || pos < fn.Pos() || pos > fn.End() {
+			continue
+		}
+
+		if recv := recvTypeName(fn); recv != "" {
+			return recv + "." + fn.Name.Name, true
+		}
+		return fn.Name.Name, true
+	}
+
+	return "", false
+}
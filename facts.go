@@ -0,0 +1,144 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+)
+
+// timeoutParamsFact lists the integer parameters of an exported function that look like timeouts, so that the
+// packages calling it can be told about them
+type timeoutParamsFact struct {
+	Params []timeoutParam
+}
+
+// timeoutParam is an integer parameter named like a duration, with the unit its name implies if any
+type timeoutParam struct {
+	Index int
+	Name  string
+	Unit  string
+}
+
+func (*timeoutParamsFact) AFact() {}
+
+func (f *timeoutParamsFact) String() string {
+	names := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		names[i] = p.Name
+	}
+
+	return fmt.Sprintf("timeoutParams(%s)", strings.Join(names, ", "))
+}
+
+// timeoutFactsAnalyzer exports a timeoutParamsFact for the exported functions with integer parameters named like
+// durations, the ones int-params reports, and returns those of the functions used by the package. It is separate from
+// Analyzer so that only this cheap pass runs over the dependencies of the analyzed packages.
+var timeoutFactsAnalyzer = &analysis.Analyzer{
+	Name:       "durationcheckfacts",
+	Doc:        "export the integer parameters of exported functions named like durations",
+	Run:        runTimeoutFacts,
+	FactTypes:  []analysis.Fact{new(timeoutParamsFact)},
+	ResultType: reflect.TypeOf(timeoutParams(nil)),
+
+	// the facts are best effort on packages with errors, for the analyzers running despite them
+	RunDespiteErrors: true,
+}
+
+// timeoutParams maps the functions of other packages to their integer parameters named like durations
+type timeoutParams map[*types.Func][]timeoutParam
+
+func runTimeoutFacts(pass *analysis.Pass) (interface{}, error) {
+	// the facts are exported whatever the settings of the analyzers requiring this one, which it doesn't know
+	if pass.TypesInfo == nil {
+		return timeoutParams(nil), nil
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				exportTimeoutParams(pass, fn)
+			}
+		}
+	}
+
+	params := timeoutParams{}
+	for _, obj := range pass.TypesInfo.Uses {
+		fn, ok := obj.(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+			continue
+		}
+
+		var fact timeoutParamsFact
+		if pass.ImportObjectFact(fn.Origin(), &fact) {
+			params[fn.Origin()] = fact.Params
+		}
+	}
+
+	return params, nil
+}
+
+// exportTimeoutParams exports the integer parameters named like durations of an exported function, if any
+func exportTimeoutParams(pass *analysis.Pass, decl *ast.FuncDecl) {
+	if !decl.Name.IsExported() || !isExportedReceiver(decl) {
+		return
+	}
+
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+
+	fact := &timeoutParamsFact{}
+	params := fn.Signature().Params()
+	for i := range params.Len() {
+		param := params.At(i)
+		if !isInteger(param.Type()) || durationexpr.IsDuration(param.Type()) || !isDurationName(param.Name()) {
+			continue
+		}
+
+		var unit string
+		if words := splitWords(param.Name()); len(words) > 0 {
+			unit = unitWords[words[len(words)-1]]
+		}
+		fact.Params = append(fact.Params, timeoutParam{Index: i, Name: param.Name(), Unit: unit})
+	}
+
+	if len(fact.Params) > 0 {
+		pass.ExportObjectFact(fn, fact)
+	}
+}
+
+// checkTimeoutCall reports calls to functions of other packages taking integer timeouts, as listed by their facts.
+// StandaloneAnalyzer doesn't have the facts.
+func (c *checker) checkTimeoutCall(call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return
+	}
+
+	for _, param := range c.timeouts[fn.Origin()] {
+		if param.Index >= len(call.Args) {
+			continue
+		}
+
+		// the facts don't know the duration types of the settings, e.g. model.Duration with -duration-types
+		if params := fn.Origin().Signature().Params(); param.Index < params.Len() && c.classifier.IsDuration(params.At(param.Index).Type()) {
+			continue
+		}
+
+		arg := call.Args[param.Index]
+		if param.Unit == "" {
+			c.reportf(RuleTimeoutCalls, arg, "`%s` expects an integer timeout `%s` in an unspecified unit: consider a Duration-based wrapper",
+				fn.Name(), param.Name)
+			continue
+		}
+
+		c.reportf(RuleTimeoutCalls, arg, "`%s` expects %ss as int for `%s`: consider a Duration-based wrapper",
+			fn.Name(), strings.ToLower(param.Unit), param.Name)
+	}
+}
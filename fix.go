@@ -0,0 +1,278 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// FixAnalyzer suggests safe rewrites of duration expressions into their canonical forms:
+//
+//	time.Now().Sub(t)     => time.Since(t)
+//	t.Sub(time.Now())     => time.Until(t)
+//	(d / unit) * unit     => d.Truncate(unit)
+//	1000 * time.Millisecond => time.Second
+//
+// Every diagnostic carries a suggested fix. It is used by the durationfix command.
+var FixAnalyzer = &analysis.Analyzer{
+	Name:     "durationfix",
+	Doc:      "suggest canonical forms of duration expressions",
+	Run:      runFix,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// Categories of the diagnostics of FixAnalyzer, one per rewrite.
+const (
+	categorySince        = "since"
+	categoryUntil        = "until"
+	categoryTruncate     = "truncate"
+	categoryUnitConstant = "unit-constant"
+)
+
+// units are the time package unit constants, from the largest to the smallest
+var units = []struct {
+	name  string
+	value int64
+}{
+	{"Hour", 60 * 60 * 1e9},
+	{"Minute", 60 * 1e9},
+	{"Second", 1e9},
+	{"Millisecond", 1e6},
+	{"Microsecond", 1e3},
+	{"Nanosecond", 1},
+}
+
+func runFix(pass *analysis.Pass) (interface{}, error) {
+	if !hasImport(pass.Pkg, "time") {
+		return nil, nil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeTypes := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.BinaryExpr)(nil),
+	}
+
+	inspect.Preorder(nodeTypes, func(node ast.Node) {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			fixSub(pass, n)
+		case *ast.BinaryExpr:
+			if !fixTruncate(pass, n) {
+				fixUnitConstant(pass, n)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// fixSub rewrites time.Now().Sub(t) into time.Since(t) and t.Sub(time.Now()) into time.Until(t)
+func fixSub(pass *analysis.Pass, call *ast.CallExpr) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Sub" || len(call.Args) != 1 || !isTimeMethod(pass, selector.Sel) {
+		return
+	}
+
+	if qualifier, ok := timeNowCall(pass, selector.X); ok {
+		suggestFix(pass, call, categorySince, "use time.Since", qualifier+".Since("+formatNode(call.Args[0])+")")
+		return
+	}
+
+	if qualifier, ok := timeNowCall(pass, call.Args[0]); ok {
+		suggestFix(pass, call, categoryUntil, "use time.Until", qualifier+".Until("+formatNode(selector.X)+")")
+	}
+}
+
+// fixTruncate rewrites the truncation idiom (d / unit) * unit into d.Truncate(unit)
+func fixTruncate(pass *analysis.Pass, expr *ast.BinaryExpr) bool {
+	d, unit, ok := truncationIdiom(pass, expr)
+	if !ok {
+		return false
+	}
+
+	suggestFix(pass, expr, categoryTruncate, "use Duration.Truncate", formatOperand(d)+".Truncate("+formatNode(unit)+")")
+	return true
+}
+
+// truncationIdiom matches (d / unit) * unit, or unit * (d / unit), and returns d and unit. The unit is a unit constant
+// or a duration variable, e.g. `(d / interval) * interval`, but not a call that could return another value each time.
+func truncationIdiom(pass *analysis.Pass, expr *ast.BinaryExpr) (ast.Expr, ast.Expr, bool) {
+	if expr.Op != token.MUL {
+		return nil, nil, false
+	}
+
+	quo, ok := ast.Unparen(expr.X).(*ast.BinaryExpr)
+	unit := expr.Y
+	if !ok || quo.Op != token.QUO {
+		quo, ok = ast.Unparen(expr.Y).(*ast.BinaryExpr)
+		unit = expr.X
+	}
+	if !ok || quo.Op != token.QUO {
+		return nil, nil, false
+	}
+
+	if !durationexpr.IsDuration(pass.TypesInfo.TypeOf(quo.X)) || !isTruncationUnit(pass, unit) {
+		return nil, nil, false
+	}
+
+	if formatNode(ast.Unparen(quo.Y)) != formatNode(ast.Unparen(unit)) {
+		return nil, nil, false
+	}
+
+	return quo.X, unit, true
+}
+
+// isTruncationUnit returns true if the expression is a unit constant or a duration variable or field
+func isTruncationUnit(pass *analysis.Pass, expr ast.Expr) bool {
+	if isUnitConstant(pass, expr) {
+		return true
+	}
+
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return false
+	}
+
+	_, isVar := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	return isVar && durationexpr.IsDuration(pass.TypesInfo.TypeOf(expr))
+}
+
+// fixUnitConstant rewrites constant multiples of a unit that are exactly another unit, e.g. 60 * time.Second
+func fixUnitConstant(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL {
+		return
+	}
+
+	lit, unit := expr.X, expr.Y
+	if _, ok := ast.Unparen(lit).(*ast.BasicLit); !ok {
+		lit, unit = expr.Y, expr.X
+	}
+
+	if _, ok := ast.Unparen(lit).(*ast.BasicLit); !ok || !isUnitConstant(pass, unit) {
+		return
+	}
+
+	qualifier, ok := unitQualifier(unit)
+	if !ok {
+		return
+	}
+
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil {
+		return
+	}
+
+	value, exact := constant.Int64Val(tv.Value)
+	if !exact {
+		return
+	}
+
+	for _, u := range units {
+		if u.value == value {
+			suggestFix(pass, expr, categoryUnitConstant, "use time."+u.name, qualifier+"."+u.name)
+			return
+		}
+	}
+}
+
+func suggestFix(pass *analysis.Pass, node ast.Node, category, message, replacement string) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: category,
+		Message:  message + ": `" + replacement + "`",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: message,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     node.Pos(),
+				End:     node.End(),
+				NewText: []byte(replacement),
+			}},
+		}},
+	})
+}
+
+// formatOperand formats an expression so that a method can be called on it
+func formatOperand(expr ast.Expr) string {
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.ParenExpr, *ast.IndexExpr:
+		return formatNode(expr)
+	default:
+		return "(" + formatNode(expr) + ")"
+	}
+}
+
+// timeNowCall reports whether expr is a call to time.Now and returns the package qualifier used in the call
+func timeNowCall(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return "", false
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Now" {
+		return "", false
+	}
+
+	fn, ok := pass.TypesInfo.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "time" {
+		return "", false
+	}
+
+	return unitQualifier(selector)
+}
+
+// isTimeMethod reports whether the identifier refers to a method declared in the time package
+func isTimeMethod(pass *analysis.Pass, ident *ast.Ident) bool {
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "time"
+}
+
+// isUnitConstant reports whether expr refers to one of the unit constants of the time package
+func isUnitConstant(pass *analysis.Pass, expr ast.Expr) bool {
+	selector, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	c, ok := pass.TypesInfo.Uses[selector.Sel].(*types.Const)
+	if !ok || c.Pkg() == nil || c.Pkg().Path() != "time" {
+		return false
+	}
+
+	for _, u := range units {
+		if u.name == c.Name() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unitQualifier returns the package identifier of a qualified reference such as time.Second
+func unitQualifier(expr ast.Expr) (string, bool) {
+	selector, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	pkg, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+
+	return pkg.Name, true
+}
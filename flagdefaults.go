@@ -0,0 +1,103 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// flagDefaults maps the functions and methods defining duration flags in the flag and pflag packages to the index of
+// their default value argument
+var flagDefaults = map[string]int{
+	"Duration":     1, // Duration(name, value, usage)
+	"DurationP":    2, // DurationP(name, shorthand, value, usage)
+	"DurationVar":  2, // DurationVar(p, name, value, usage)
+	"DurationVarP": 3, // DurationVarP(p, name, shorthand, value, usage)
+}
+
+// checkFlagDefault reports duration flags defined with bare numbers as default values, e.g.
+// `flag.Duration("timeout", 30, "...")` which defaults to 30 nanoseconds
+func (c *checker) checkFlagDefault(call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil || fn.Pkg() == nil || !contains(c.settings.flagPackages, fn.Pkg().Path()) {
+		return
+	}
+
+	i, ok := flagDefaults[fn.Name()]
+	if !ok || i >= len(call.Args) {
+		return
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if i >= sig.Params().Len() || !c.classifier.IsDuration(sig.Params().At(i).Type()) {
+		return
+	}
+
+	value := call.Args[i]
+	if !isNumberLiteral(value) {
+		return
+	}
+
+	n, exact := constant.Int64Val(constant.ToInt(c.pass.TypesInfo.Types[value].Value))
+	if !exact || n <= int64(c.settings.unscaledThreshold) && n >= -int64(c.settings.unscaledThreshold) {
+		return
+	}
+
+	var fixes []analysis.SuggestedFix
+	if qualifier, ok := c.timeQualifier(); ok {
+		literal := formatNode(value)
+		for _, unit := range fixUnits {
+			fixes = append(fixes, analysis.SuggestedFix{
+				Message:   "Scale by " + qualifier + "." + unit,
+				TextEdits: []analysis.TextEdit{{Pos: value.Pos(), End: value.End(), NewText: []byte(literal + " * " + qualifier + "." + unit)}},
+			})
+		}
+	}
+
+	c.reportFixf(RuleFlagDefault, value, fixes, "Default value `%s` of duration flag is %d nanoseconds: multiply it by a unit",
+		c.formatExpr(value), n)
+}
+
+// calledFunc returns the function or method called, or nil
+func (c *checker) calledFunc(call *ast.CallExpr) *types.Func {
+	return calledFunc(c.pass.TypesInfo, call)
+}
+
+// calledFunc is like checker.calledFunc for the analyzers without a checker
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// timeQualifier returns the name under which the file being checked imports the time package
+func (c *checker) timeQualifier() (string, bool) {
+	for _, imp := range c.file.Imports {
+		if path, err := strconv.Unquote(imp.Path.Value); err != nil || path != "time" {
+			continue
+		}
+
+		if imp.Name == nil {
+			return "time", true
+		}
+
+		if imp.Name.Name == "_" || imp.Name.Name == "." {
+			return "", false
+		}
+		return imp.Name.Name, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,34 @@
+package durationcheck
+
+import "strings"
+
+// stringsFlag is a flag holding a comma-separated list of strings
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = nil
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*f = append(*f, s)
+		}
+	}
+
+	return nil
+}
+
+// repeatedFlag is a flag holding the values of its occurrences, for lists whose values may contain commas such as
+// regular expressions
+type repeatedFlag []string
+
+func (f *repeatedFlag) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *repeatedFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,77 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkFloatConversion reports float values converted to durations before being scaled by a unit, which truncates
+// their fractional part, e.g. `time.Duration(seconds) * time.Second` where seconds is 2.7
+func (c *checker) checkFloatConversion(expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL {
+		return
+	}
+
+	for _, operands := range [][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+		call := c.floatConversion(operands[0])
+		if call == nil {
+			continue
+		}
+
+		unit := operands[1]
+		if !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(unit)) || c.classifier.Classify(unit) != durationexpr.Unit {
+			continue
+		}
+
+		arg := call.Args[0]
+		replacement := c.scaledFloat(call, arg, unit)
+		fixes := []analysis.SuggestedFix{{
+			Message:   "Scale the float before converting it",
+			TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(replacement)}},
+		}}
+
+		c.reportFixf(RuleFloatCount, expr, fixes, "Conversion of float `%s` to duration truncates it before scaling: use `%s`",
+			c.formatExpr(arg), truncate(replacement, c.settings.maxExprLen))
+		return
+	}
+}
+
+// scaledFloat returns the conversion of the float scaled by the unit, e.g. `time.Duration(secs * float64(time.Second))`.
+// Floats of another type than float64, e.g. float32 or a named type, are converted to float64 first.
+func (c *checker) scaledFloat(call *ast.CallExpr, arg, unit ast.Expr) string {
+	operand := formatOperand(arg)
+	if !types.Identical(c.pass.TypesInfo.TypeOf(arg), types.Typ[types.Float64]) {
+		operand = "float64(" + formatNode(arg) + ")"
+	}
+
+	return formatNode(call.Fun) + "(" + operand + " * float64(" + formatNode(unit) + "))"
+}
+
+// floatConversion returns the conversion of a non-constant float to time.Duration the expression is, or nil
+func (c *checker) floatConversion(expr ast.Expr) *ast.CallExpr {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !c.classifier.IsConversion(call) {
+		return nil
+	}
+
+	arg := call.Args[0]
+	tv, ok := c.pass.TypesInfo.Types[arg]
+	if !ok {
+		c.incompletef(arg, "the type of the converted value is unknown")
+		return nil
+	}
+
+	if tv.Value != nil {
+		return nil
+	}
+
+	if basic, ok := tv.Type.Underlying().(*types.Basic); !ok || basic.Info()&types.IsFloat == 0 {
+		return nil
+	}
+
+	return call
+}
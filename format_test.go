@@ -0,0 +1,50 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestFormatNodeFallback(t *testing.T) {
+	if got := formatNode(&ast.Field{}); got != "" {
+		t.Errorf("formatNode() = %q, want an empty string", got)
+	}
+
+	if got := formatNode(&ast.BinaryExpr{X: ast.NewIdent("d"), Op: token.MUL, Y: ast.NewIdent("unit")}); got != "d * unit" {
+		t.Errorf("formatNode() = %q, want d * unit", got)
+	}
+}
+
+func TestFormatExprSource(t *testing.T) {
+	const src = "package p\n\nfunc f(timeout   int) {}\n"
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &checker{
+		pass: &analysis.Pass{
+			Fset: fset,
+			ReadFile: func(filename string) ([]byte, error) {
+				if filename != "p.go" {
+					return nil, os.ErrNotExist
+				}
+				return []byte(src), nil
+			},
+		},
+		settings: newSettings(),
+	}
+
+	// fields can't be formatted on their own, the source is quoted instead
+	field := file.Decls[0].(*ast.FuncDecl).Type.Params.List[0]
+	if got := c.formatExpr(field); got != "timeout   int" {
+		t.Errorf("formatExpr() = %q, want the source of the field", got)
+	}
+}
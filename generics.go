@@ -0,0 +1,143 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// genericProduct is a multiplication of operands typed by a type parameter of a generic function, e.g. `a * b` in
+// `func mul[T int64 | time.Duration](a, b T) T { return a * b }`, which multiplies durations when the type parameter
+// is instantiated with one
+type genericProduct struct {
+	expr  *ast.BinaryExpr
+	param *types.TypeParam
+}
+
+// checkGenericInstantiation reports calls instantiating a generic function of the package with a duration for a type
+// parameter typing at least two operands of one of its multiplications, such as `mul(d, time.Second)`
+func (c *checker) checkGenericInstantiation(call *ast.CallExpr) {
+	ident := genericFuncIdent(call.Fun)
+	if ident == nil {
+		return
+	}
+
+	inst, ok := c.pass.TypesInfo.Instances[ident]
+	if !ok {
+		return
+	}
+
+	fn, ok := c.pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok {
+		return
+	}
+
+	for _, product := range c.genericProductsOf(fn.Origin()) {
+		// type parameters only holding durations are reported in the generic function itself
+		if product.param.Index() >= inst.TypeArgs.Len() || c.classifier.IsDuration(product.param) {
+			continue
+		}
+
+		arg := inst.TypeArgs.At(product.param.Index())
+		if !c.classifier.IsDuration(arg) {
+			continue
+		}
+
+		c.reportf(RuleMul, call, "Multiplication of durations in `%s` instantiated with %s: `%s`",
+			fn.Name(), arg, c.formatExpr(product.expr))
+		return
+	}
+}
+
+// genericFuncIdent returns the identifier naming the called function, through explicit instantiations
+func genericFuncIdent(fun ast.Expr) *ast.Ident {
+	switch fun := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	case *ast.IndexExpr:
+		return genericFuncIdent(fun.X)
+	case *ast.IndexListExpr:
+		return genericFuncIdent(fun.X)
+	default:
+		return nil
+	}
+}
+
+// genericProductsOf returns the multiplications of the generic function of the package, indexing those of every
+// generic function on first use. Methods of generic types aren't indexed.
+func (c *checker) genericProductsOf(fn *types.Func) []genericProduct {
+	if c.genericProducts == nil {
+		c.genericProducts = map[*types.Func][]genericProduct{}
+		for _, file := range c.pass.Files {
+			for _, decl := range file.Decls {
+				if decl, ok := decl.(*ast.FuncDecl); ok && decl.Type.TypeParams != nil && decl.Body != nil {
+					if obj, ok := c.pass.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+						c.genericProducts[obj] = c.findGenericProducts(decl.Body)
+					}
+				}
+			}
+		}
+	}
+
+	return c.genericProducts[fn]
+}
+
+func (c *checker) findGenericProducts(body *ast.BlockStmt) []genericProduct {
+	var products []genericProduct
+
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		expr, ok := n.(*ast.BinaryExpr)
+		if !ok || expr.Op != token.MUL {
+			return true
+		}
+
+		operands := multiplicationOperands(expr)
+
+		// the operands of a multiplication have the same type
+		var param *types.TypeParam
+		typed := 0
+		for _, op := range operands {
+			if tp, ok := c.typeParamOperand(op); ok {
+				param = tp
+				typed++
+			}
+		}
+
+		if typed >= 2 {
+			products = append(products, genericProduct{expr: expr, param: param})
+		}
+
+		for _, op := range operands {
+			ast.Inspect(op, visit)
+		}
+		return false
+	}
+	ast.Inspect(body, visit)
+
+	return products
+}
+
+// typeParamOperand returns the type parameter typing the operand if it's neither a constant nor a conversion, which
+// are counts like in non-generic code
+func (c *checker) typeParamOperand(op ast.Expr) (*types.TypeParam, bool) {
+	tv, ok := c.pass.TypesInfo.Types[op]
+	if !ok || tv.Value != nil {
+		return nil, false
+	}
+
+	tp, ok := types.Unalias(tv.Type).(*types.TypeParam)
+	if !ok {
+		return nil, false
+	}
+
+	if call, ok := ast.Unparen(op).(*ast.CallExpr); ok {
+		if fun, ok := c.pass.TypesInfo.Types[call.Fun]; ok && fun.IsType() {
+			return nil, false
+		}
+	}
+
+	return tp, true
+}
@@ -1,5 +1,13 @@
 module github.com/charithe/durationcheck
 
-go 1.14
+go 1.24.0
 
-require golang.org/x/tools v0.1.0
+require (
+	golang.org/x/tools v0.38.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/mod v0.29.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+)
@@ -0,0 +1,160 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+)
+
+// durationResultFact is the kind of the durations a function always returns, e.g. Count for
+// `func Retries(n int) time.Duration { return time.Duration(n) }` and Unit for
+// `func Seconds(n int) time.Duration { return time.Duration(n) * time.Second }`, so that the packages calling it
+// classify its calls like its results
+type durationResultFact struct {
+	Kind durationexpr.Kind
+}
+
+func (*durationResultFact) AFact() {}
+
+func (f *durationResultFact) String() string {
+	return fmt.Sprintf("durationResult(%s)", f.Kind)
+}
+
+// resultFactsAnalyzer exports a durationResultFact for the exported functions returning a single duration whose
+// return statements agree on its kind, and returns the kinds of the results of the functions of the package and of
+// those it uses from other packages. Like timeoutFactsAnalyzer, it's separate from Analyzer to keep the pass over the
+// dependencies cheap.
+var resultFactsAnalyzer = &analysis.Analyzer{
+	Name:       "durationcheckresults",
+	Doc:        "export the kind of the durations returned by exported functions",
+	Run:        runResultFacts,
+	FactTypes:  []analysis.Fact{new(durationResultFact)},
+	ResultType: reflect.TypeOf(map[*types.Func]durationexpr.Kind(nil)),
+
+	// best effort on packages with errors, like timeoutFactsAnalyzer
+	RunDespiteErrors: true,
+}
+
+func runResultFacts(pass *analysis.Pass) (interface{}, error) {
+	if pass.TypesInfo == nil {
+		return map[*types.Func]durationexpr.Kind(nil), nil
+	}
+
+	results := map[*types.Func]durationexpr.Kind{}
+	for _, obj := range pass.TypesInfo.Uses {
+		fn, ok := obj.(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+			continue
+		}
+
+		var fact durationResultFact
+		if pass.ImportObjectFact(fn.Origin(), &fact) {
+			results[fn.Origin()] = fact.Kind
+		}
+	}
+
+	for decl, kind := range classifyResults(pass, results) {
+		if !decl.Name.IsExported() || !isExportedReceiver(decl) {
+			continue
+		}
+
+		pass.ExportObjectFact(pass.TypesInfo.Defs[decl.Name].(*types.Func), &durationResultFact{Kind: kind})
+	}
+
+	return results, nil
+}
+
+// classifyResults adds to results the kind of the durations returned by the functions of the package, and returns
+// their declarations. The functions are classified until none changes, since a function may return the result of
+// another one declared after it.
+func classifyResults(pass *analysis.Pass, results map[*types.Func]durationexpr.Kind) map[*ast.FuncDecl]durationexpr.Kind {
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil && returnsDuration(pass.TypesInfo, decl) {
+				decls = append(decls, decl)
+			}
+		}
+	}
+
+	classifier := &durationexpr.Classifier{Info: pass.TypesInfo, Results: results}
+	classified := map[*ast.FuncDecl]durationexpr.Kind{}
+
+	// each round settles the functions returning the results of those settled by the previous one
+	for range len(decls) + 1 {
+		changed := false
+		for _, decl := range decls {
+			kind, ok := resultKind(classifier, decl)
+			if prev, seen := classified[decl]; !ok || (seen && prev == kind) {
+				continue
+			}
+
+			classified[decl] = kind
+			results[pass.TypesInfo.Defs[decl.Name].(*types.Func)] = kind
+			changed = true
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	return classified
+}
+
+// returnsDuration returns true if the function returns a single time.Duration, not a pointer to it
+func returnsDuration(info *types.Info, decl *ast.FuncDecl) bool {
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+
+	results := fn.Signature().Results()
+	if results.Len() != 1 {
+		return false
+	}
+
+	_, isPointer := types.Unalias(results.At(0).Type()).(*types.Pointer)
+	return !isPointer && durationexpr.IsDuration(results.At(0).Type())
+}
+
+// resultKind returns the kind of the durations returned by the function, false if its return statements disagree, if
+// one of them is bare or if they all return constants
+func resultKind(classifier *durationexpr.Classifier, decl *ast.FuncDecl) (durationexpr.Kind, bool) {
+	var kinds []durationexpr.Kind
+	ok := true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != 1 {
+				ok = false
+				return false
+			}
+
+			// constants, such as the zero value of stubs, don't tell counts from units
+			if tv, ok := classifier.Info.Types[n.Results[0]]; !ok || tv.Value == nil {
+				kinds = append(kinds, classifier.Classify(n.Results[0]))
+			}
+		}
+
+		return ok
+	})
+
+	if !ok || len(kinds) == 0 {
+		return 0, false
+	}
+
+	for _, kind := range kinds[1:] {
+		if kind != kinds[0] {
+			return 0, false
+		}
+	}
+
+	return kinds[0], true
+}
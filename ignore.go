@@ -0,0 +1,48 @@
+package durationcheck
+
+import "go/ast"
+
+// ignored returns true if the reported node calls a function or contains an identifier that the configuration
+// accepts, or is formatted as an expression matching one of its patterns
+func (c *checker) ignored(node ast.Node) bool {
+	if c.config == nil {
+		return false
+	}
+
+	ignore := &c.config.Ignore
+
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.Ident:
+			found = contains(ignore.Identifiers, n.Name)
+		case *ast.CallExpr:
+			if fn := c.calledFunc(n); fn != nil {
+				found = contains(ignore.Functions, funcKey(fn))
+			}
+		}
+
+		return !found
+	})
+
+	if found || len(ignore.expressions) == 0 {
+		return found
+	}
+
+	s, err := printNode(node)
+	if err != nil {
+		return false
+	}
+
+	for _, re := range ignore.expressions {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
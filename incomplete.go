@@ -0,0 +1,30 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryIncomplete is the category of the diagnostics about expressions the checks could not analyze
+const categoryIncomplete = "incomplete"
+
+// incompletef reports, with -report-incomplete, an expression that the checks skipped or could not describe, so that
+// gaps in the coverage of the analysis don't go unnoticed
+func (c *checker) incompletef(node ast.Node, format string, args ...interface{}) {
+	if !c.settings.reportIncomplete {
+		return
+	}
+
+	if d := c.suppression(node); d != nil && !d.expired() {
+		return
+	}
+
+	c.report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: categoryIncomplete,
+		Message:  fmt.Sprintf(c.config.translate("Analysis incomplete here: %s"), fmt.Sprintf(c.config.translate(format), args...)),
+	})
+}
@@ -0,0 +1,64 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+func TestReportIncomplete(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", "package p\n\nvar _ = a * b\n", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mul inspector.Cursor
+	for cur := range inspector.New([]*ast.File{file}).Root().Preorder((*ast.BinaryExpr)(nil)) {
+		mul = cur
+	}
+
+	for _, enabled := range []bool{false, true} {
+		s := newSettings()
+		s.reportIncomplete = enabled
+
+		var diagnostics []analysis.Diagnostic
+		info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}}
+		c := &checker{
+			pass: &analysis.Pass{
+				Fset:      fset,
+				TypesInfo: info,
+				Report:    func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) },
+			},
+			settings:   s,
+			classifier: &durationexpr.Classifier{Info: info},
+			enabled:    map[string]bool{RuleMul: true},
+		}
+
+		// the operands have no type information
+		c.checkMultiplication(mul, mul.Node().(*ast.BinaryExpr))
+		// fields can't be formatted on their own
+		c.formatExpr(&ast.Field{})
+
+		want := 0
+		if enabled {
+			want = 2
+		}
+
+		if len(diagnostics) != want {
+			t.Fatalf("-report-incomplete=%t: got %d diagnostics, want %d: %v", enabled, len(diagnostics), want, diagnostics)
+		}
+
+		for _, d := range diagnostics {
+			if d.Category != categoryIncomplete {
+				t.Errorf("got category %q, want %q", d.Category, categoryIncomplete)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package pathmatch matches file paths against slash-separated glob patterns.
+package pathmatch
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Match reports whether the file matches the pattern.
+//
+// Patterns are slash-separated globs where `**` matches any number of path elements. A pattern ending with a slash
+// matches every file below that directory. A pattern starting with a slash must match the whole path, any other
+// pattern may match starting at any directory of the path.
+func Match(pattern, filename string) bool {
+	filename = filepath.ToSlash(filename)
+
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	patternElems := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	elems := strings.Split(strings.TrimPrefix(filename, "/"), "/")
+
+	if strings.HasPrefix(pattern, "/") {
+		return matchElems(patternElems, elems)
+	}
+
+	for i := range elems {
+		if matchElems(patternElems, elems[i:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchElems(pattern, elems []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(elems); i++ {
+				if matchElems(pattern[1:], elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(elems) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+			return false
+		}
+
+		pattern, elems = pattern[1:], elems[1:]
+	}
+
+	return len(elems) == 0
+}
+
+// Validate returns an error if the pattern is malformed.
+func Validate(pattern string) error {
+	if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package pathmatch
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		filename string
+		want     bool
+	}{
+		{pattern: "internal/clock/", filename: "/src/project/internal/clock/clock.go", want: true},
+		{pattern: "internal/clock/", filename: "/src/project/internal/clock/fake/fake.go", want: true},
+		{pattern: "internal/clock/", filename: "/src/project/internal/clocks/clock.go", want: false},
+		{pattern: "*_test.go", filename: "/src/project/a/a_test.go", want: true},
+		{pattern: "*_test.go", filename: "/src/project/a/a.go", want: false},
+		{pattern: "a/*.go", filename: "/src/project/a/a.go", want: true},
+		{pattern: "a/*.go", filename: "/src/project/a/b/b.go", want: false},
+		{pattern: "a/**/*.go", filename: "/src/project/a/b/b.go", want: true},
+		{pattern: "a/**/*.go", filename: "/src/project/a/a.go", want: true},
+		{pattern: "/src/project/a/", filename: "/src/project/a/a.go", want: true},
+		{pattern: "/project/a/", filename: "/src/project/a/a.go", want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := Match(tc.pattern, tc.filename); got != tc.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tc.pattern, tc.filename, got, tc.want)
+		}
+	}
+}
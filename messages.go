@@ -0,0 +1,61 @@
+package durationcheck
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Messages translates the diagnostic messages.
+type Messages struct {
+	// Catalog is a YAML file mapping the message formats of the diagnostics, as written in the source, to their
+	// translations, e.g. "Multiplication of durations: `%s`": "期間同士の乗算: `%s`". Relative paths are resolved
+	// from the directory of the configuration file.
+	Catalog string `yaml:"catalog"`
+
+	catalog map[string]string
+}
+
+func loadCatalog(filename string) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading message catalog: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	catalog := map[string]string{}
+	if err := dec.Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("parsing message catalog %s: %w", filename, err)
+	}
+
+	for format, translation := range catalog {
+		if countVerbs(format) != countVerbs(translation) {
+			return nil, fmt.Errorf("message catalog %s: translation %q doesn't have the %d arguments of %q", filename, translation, countVerbs(format), format)
+		}
+	}
+
+	return catalog, nil
+}
+
+// countVerbs returns the number of formatting verbs of a format, `%%` excluded
+func countVerbs(format string) int {
+	return strings.Count(format, "%") - 2*strings.Count(format, "%%")
+}
+
+// translate returns the translation of the message format, or the format itself if the catalog doesn't have one
+func (c *Config) translate(format string) string {
+	if c == nil {
+		return format
+	}
+
+	if translation, ok := c.Messages.catalog[format]; ok {
+		return translation
+	}
+
+	return format
+}
@@ -0,0 +1,36 @@
+package durationcheck
+
+import "golang.org/x/tools/go/analysis"
+
+// Middleware inspects each diagnostic of Analyzer and StandaloneAnalyzer before it is reported. It returns the
+// diagnostic to report, possibly modified, or false to drop it.
+type Middleware func(pass *analysis.Pass, diag analysis.Diagnostic) (analysis.Diagnostic, bool)
+
+// middleware is the middleware set with SetMiddleware
+var middleware Middleware
+
+// SetMiddleware sets the middleware applied to the diagnostics, e.g. to link internal runbooks from the messages or
+// to apply organization specific suppressions without forking the analyzer. A nil middleware reports the
+// diagnostics unchanged.
+func SetMiddleware(m Middleware) {
+	middleware = m
+}
+
+// report reports the diagnostic through the middleware, returning the reported diagnostic or false if the middleware
+// dropped it. The diagnostic links to the documentation of its category, which the middleware may replace.
+func (c *checker) report(diag analysis.Diagnostic) (analysis.Diagnostic, bool) {
+	if diag.URL == "" {
+		diag.URL = RuleURL(diag.Category)
+	}
+
+	if middleware != nil {
+		var ok bool
+		if diag, ok = middleware(c.pass, diag); !ok {
+			return diag, false
+		}
+	}
+
+	c.pass.Report(diag)
+
+	return diag, true
+}
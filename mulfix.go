@@ -0,0 +1,194 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// multiplicationFixes returns the rewrite of a multiplication of durations where one operand is a unit constant,
+// e.g. `interval * time.Second`, when the other operand already holds the whole duration and the unit is redundant,
+// giving `interval`. A duration holding a count of the unit has no rewrite: converting it to an integer and back, e.g.
+// `time.Duration(int64(interval)) * time.Second`, still multiplies two durations.
+func (c *checker) multiplicationFixes(cur inspector.Cursor, expr *ast.BinaryExpr) []analysis.SuggestedFix {
+	operand, unit := expr.X, expr.Y
+	if !isUnitConstant(c.pass, unit) {
+		operand, unit = unit, operand
+	}
+
+	if !isUnitConstant(c.pass, unit) || isUnitConstant(c.pass, operand) {
+		return nil
+	}
+
+	if c.isElapsed(operand) {
+		return c.elapsedFixes(cur, expr, operand, unit)
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Drop the redundant unit",
+		TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(formatNode(operand))}},
+	}}
+}
+
+// elapsedFixes returns the rewrite of an elapsed time re-scaled by a unit, e.g. `time.Since(start) *
+// time.Millisecond`. The elapsed time is already a duration, so the rewrite drops the unit, unless the product is
+// assigned to a name ending with the unit, e.g. `elapsedMs`, in which case the intent is to count whole units and the
+// rewrite truncates the elapsed time to the unit.
+func (c *checker) elapsedFixes(cur inspector.Cursor, expr *ast.BinaryExpr, operand, unit ast.Expr) []analysis.SuggestedFix {
+	if name := assignedName(cur); name != "" && nameUnit(name) == ast.Unparen(unit).(*ast.SelectorExpr).Sel.Name {
+		return []analysis.SuggestedFix{{
+			Message:   "Truncate the elapsed time to the unit",
+			TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(formatOperand(operand) + ".Truncate(" + formatNode(unit) + ")")}},
+		}}
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Drop the unit, the elapsed time is already a duration",
+		TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(formatNode(operand))}},
+	}}
+}
+
+// isElapsed returns true if the expression is an elapsed time: a call to time.Since, time.Until or Time.Sub, or a
+// local variable defined as one of them and never assigned again
+func (c *checker) isElapsed(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		fn := c.calledFunc(e)
+		if fn == nil || !isTimePackage(fn.Pkg()) {
+			return false
+		}
+
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			return fn.Name() == "Sub" && isTimeType(recv.Type())
+		}
+
+		return fn.Name() == "Since" || fn.Name() == "Until"
+	case *ast.Ident:
+		v, ok := c.pass.TypesInfo.Uses[e].(*types.Var)
+		if !ok || v.Pkg() != c.pass.Pkg || c.file == nil {
+			return false
+		}
+
+		value, scope := c.definition(v)
+		return value != nil && c.isElapsed(value) && !c.reassigned(scope, v)
+	default:
+		return false
+	}
+}
+
+// definition returns the value a local variable is defined with, in `v := value` or `var v = value`, and the body of
+// the function declaring it
+func (c *checker) definition(v *types.Var) (ast.Expr, ast.Node) {
+	path, _ := astutil.PathEnclosingInterval(c.file, v.Pos(), v.Pos())
+
+	var value ast.Expr
+	if len(path) > 2 {
+		switch parent := path[1].(type) {
+		case *ast.AssignStmt:
+			if parent.Tok == token.DEFINE && len(parent.Lhs) == len(parent.Rhs) {
+				for i, lhs := range parent.Lhs {
+					if lhs.Pos() == v.Pos() {
+						value = parent.Rhs[i]
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(parent.Names) == len(parent.Values) {
+				for i, name := range parent.Names {
+					if name.Pos() == v.Pos() {
+						value = parent.Values[i]
+					}
+				}
+			}
+		}
+	}
+
+	for _, node := range path {
+		switch fn := node.(type) {
+		case *ast.FuncDecl:
+			return value, fn.Body
+		case *ast.FuncLit:
+			return value, fn.Body
+		}
+	}
+
+	return nil, nil
+}
+
+// reassigned returns true if the variable is assigned, incremented or has its address taken in the function body
+func (c *checker) reassigned(body ast.Node, v *types.Var) bool {
+	if body == nil {
+		return true
+	}
+
+	refers := func(expr ast.Expr) bool {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		return ok && c.pass.TypesInfo.Uses[ident] == v
+	}
+
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				found = found || refers(lhs)
+			}
+		case *ast.IncDecStmt:
+			found = found || refers(n.X)
+		case *ast.UnaryExpr:
+			found = found || n.Op == token.AND && refers(n.X)
+		}
+		return !found
+	})
+
+	return found
+}
+
+// assignedName returns the name of the variable, field or key the expression of the cursor is assigned to, if any
+func assignedName(cur inspector.Cursor) string {
+	node := cur.Node()
+	parent := cur.Parent()
+	for isParen(parent.Node()) {
+		node = parent.Node()
+		parent = parent.Parent()
+	}
+
+	switch p := parent.Node().(type) {
+	case *ast.AssignStmt:
+		if len(p.Lhs) == len(p.Rhs) {
+			for i, rhs := range p.Rhs {
+				if rhs == node {
+					return identName(p.Lhs[i])
+				}
+			}
+		}
+	case *ast.ValueSpec:
+		if len(p.Names) == len(p.Values) {
+			for i, value := range p.Values {
+				if value == node {
+					return p.Names[i].Name
+				}
+			}
+		}
+	case *ast.KeyValueExpr:
+		if p.Value == node {
+			return identName(p.Key)
+		}
+	}
+
+	return ""
+}
+
+// nameUnit returns the unit named by the last word of a name, e.g. Millisecond for `elapsedMs`, or an empty string
+func nameUnit(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return ""
+	}
+
+	return unitWords[words[len(words)-1]]
+}
@@ -0,0 +1,103 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"unicode"
+)
+
+// nonTimeWords are name components that indicate a value is a quantity that is not a time at all
+var nonTimeWords = map[string]bool{
+	"port":   true,
+	"id":     true,
+	"ids":    true,
+	"count":  true,
+	"size":   true,
+	"len":    true,
+	"length": true,
+	"index":  true,
+	"idx":    true,
+	"bytes":  true,
+	"width":  true,
+	"height": true,
+}
+
+// checkNonTimeConversions reports operands of duration arithmetic that are conversions of values
+// whose names indicate they are not times, e.g. `time.Duration(port) * time.Second`
+func (c *checker) checkNonTimeConversions(expr *ast.BinaryExpr) {
+	switch expr.Op {
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.REM:
+	default:
+		return
+	}
+
+	if !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(expr)) {
+		return
+	}
+
+	for _, operand := range []ast.Expr{expr.X, expr.Y} {
+		call, ok := operand.(*ast.CallExpr)
+		if !ok || !c.classifier.IsConversion(call) {
+			continue
+		}
+
+		if name := identName(call.Args[0]); name != "" && isNonTimeName(name) {
+			c.reportf(RuleNames, call, "Conversion of non-time value `%s` to duration: `%s`", name, c.formatExpr(expr))
+		}
+	}
+}
+
+// identName returns the name of an identifier or a field selector, or an empty string for any other expression
+func identName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// isNonTimeName returns true if the last word of a camelCase or snake_case name is a non-time word
+func isNonTimeName(name string) bool {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return false
+	}
+
+	return nonTimeWords[words[len(words)-1]]
+}
+
+// splitWords splits an identifier into lower case words, e.g. `userID` becomes [user id]
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			// an upper case rune starts a new word unless it is part of an acronym
+			prevUpper := unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !prevUpper || nextLower {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+
+	return words
+}
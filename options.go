@@ -0,0 +1,515 @@
+package durationcheck
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"strings"
+	"sync"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"github.com/charithe/durationcheck/internal/pathmatch"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// settings holds the configuration of an analyzer, set by its flags or by the options passed to NewAnalyzer
+type settings struct {
+	// optIn holds the values of the flags enabling the opt-in rules
+	optIn map[string]*bool
+	// rules are the rules enabled by WithRules
+	rules []string
+	// checks selects the rules to run, see selected
+	checks stringsFlag
+	// configFile is the path of the configuration file
+	configFile string
+	// config is the configuration set by WithConfig, which takes precedence over configFile
+	config     *Config
+	configOnce sync.Once
+	configErr  error
+	// excluded are the path patterns of the files that are not analyzed
+	excluded []string
+	// skipDirs are the path patterns of the directories whose files are not analyzed
+	skipDirs stringsFlag
+	// skipFiles are the path patterns of the files that are not analyzed, set by -skip-files
+	skipFiles stringsFlag
+	// testsOnly restricts the analysis to _test.go files
+	testsOnly bool
+	// skipTests excludes the _test.go files from the analysis
+	skipTests bool
+	// skipGenerated skips the generated files, like the generated.skip setting of the configuration
+	skipGenerated bool
+	// maxExprLen is the maximum length of an expression quoted in a diagnostic message
+	maxExprLen int
+	// unscaledThreshold is the largest bare constant that may be added to a duration variable or initialize it
+	unscaledThreshold int
+	// maxCountConst is the largest value of the duration constants classified as counts, 0 disabling it
+	maxCountConst int
+	// durationTypes are additional duration types, identified by their package path and name
+	durationTypes stringsFlag
+	// acceptNames are the words naming the duration variables that are counts in multiplications, e.g. count
+	acceptNames stringsFlag
+	// excludeExprs are the patterns of the reported expressions that aren't reported, see parseExcludedExprs
+	excludeExprs repeatedFlag
+	// dataflow classifies the operands of multiplications by tracing their values through the SSA form
+	dataflow bool
+	// strict reports every multiplication of two duration operands, whether they carry a unit or not
+	strict bool
+	// usageContext only reports the multiplications of durations whose result is used as a duration
+	usageContext bool
+	// flagPackages are the import paths of the packages whose Duration flag definitions are checked
+	flagPackages stringsFlag
+	// unitAPIs lists functions whose integer parameters expect a unit, e.g. `example.com/thirdparty.SetTimeout=ms`
+	unitAPIs stringsFlag
+	// parseFuncs lists functions returning durations parsed from strings, e.g. `github.com/spf13/viper.GetDuration`
+	parseFuncs stringsFlag
+	// profileNames are the third-party profiles whose duration wrapper types are checked
+	profileNames stringsFlag
+	// nolintMode controls the handling of golangci-lint nolint directives
+	nolintMode string
+	// debug writes how the operands of every multiplication of durations were classified to stderr
+	debug bool
+	// whyFormat is the format of the traces explaining the classification of the operands of reported multiplications
+	whyFormat string
+	// reportIncomplete reports the expressions the checks could not analyze
+	reportIncomplete bool
+	// minConfidence is the least confidence of the rules run, see RuleConfidence
+	minConfidence string
+	// trainer receives the findings instead of the driver when set, see WithTrainer
+	trainer *Trainer
+}
+
+func newSettings() *settings {
+	s := &settings{
+		optIn:             make(map[string]*bool),
+		maxExprLen:        120,
+		unscaledThreshold: 1,
+		flagPackages:      stringsFlag{"flag", "github.com/spf13/pflag"},
+		parseFuncs:        stringsFlag{"github.com/spf13/viper.GetDuration", "github.com/spf13/viper.Viper.GetDuration", "github.com/spf13/cast.ToDuration"},
+		profileNames:      stringsFlag{"kubernetes"},
+		nolintMode:        nolintOff,
+		whyFormat:         whyOff,
+		minConfidence:     ConfidenceProbable,
+	}
+
+	for _, r := range rules {
+		if r.optIn {
+			s.optIn[r.code] = new(bool)
+		}
+	}
+
+	return s
+}
+
+// register defines the flags of the settings
+func (s *settings) register(fs *flag.FlagSet) {
+	for _, r := range rules {
+		if r.optIn {
+			fs.BoolVar(s.optIn[r.code], r.code, *s.optIn[r.code], r.usage)
+		}
+	}
+	fs.Var(&s.checks, "checks", "comma-separated rules to run instead of the default ones, all for every rule; a rule prefixed by - is disabled, e.g. -checks=-mul or -checks=all,-names")
+	s.registerShared(fs)
+}
+
+// registerShared defines the flags of the settings that don't select the rules, which the analyzers of single rules
+// define as well
+func (s *settings) registerShared(fs *flag.FlagSet) {
+	fs.StringVar(&s.configFile, "config", s.configFile, "path of a configuration file")
+	fs.BoolVar(&s.testsOnly, "tests-only", s.testsOnly, "only analyze _test.go files")
+	fs.BoolVar(&s.skipTests, "skip-tests", s.skipTests, "don't analyze _test.go files")
+	fs.Var(&s.skipDirs, "skip-dirs", "comma-separated path patterns of directories whose files aren't analyzed, at any depth unless starting with /, e.g. vendor,third_party,api/client")
+	fs.Var(&s.skipFiles, "skip-files", "comma-separated path patterns of files that aren't analyzed, ** matching any number of directories, e.g. *_gen.go,**/mocks/*.go")
+	fs.BoolVar(&s.skipGenerated, "skip-generated", s.skipGenerated, "skip the generated files, those with a \"Code generated ... DO NOT EDIT.\" header or recognized by the configuration")
+	fs.IntVar(&s.maxExprLen, "max-expr-len", s.maxExprLen, "truncate expressions quoted in diagnostic messages to this many characters (0 means no limit)")
+	fs.IntVar(&s.unscaledThreshold, "unscaled-threshold", s.unscaledThreshold, "largest bare integer constant accepted added to a duration variable (unscaled-add), initializing one (bare-init), as a flag default (flag-default) or compared to a duration (compare-literal)")
+	fs.IntVar(&s.maxCountConst, "max-count-const", s.maxCountConst, "treat constants of type time.Duration whose value is at most N as counts in multiplications, e.g. const retries time.Duration = 3 (0 disables it)")
+	fs.Var(&s.durationTypes, "duration-types", "comma-separated additional duration types checked like time.Duration, identified by their package path and name, e.g. github.com/prometheus/common/model.Duration")
+	fs.Var(&s.excludeExprs, "exclude-expr", "regular expression matched against the reported expressions, formatted with gofmt, to suppress intentional patterns, optionally followed by @ and one matched against the name of the enclosing function, e.g. 'jitter \\* spread@^Backoff$' (repeatable)")
+	fs.Var(&s.acceptNames, "accept-names", "comma-separated words naming duration variables and fields treated as counts in multiplications, e.g. factor,multiplier,count,retries to accept backoff * retryCount")
+	fs.BoolVar(&s.dataflow, "dataflow", s.dataflow, "trace the operands of multiplications through local variables to tell durations converted from counts from durations carrying a unit")
+	fs.BoolVar(&s.strict, "strict", s.strict, "report every multiplication of two durations, even time.Duration(n) * time.Second; untyped constants such as 2 in 2 * time.Second are still accepted")
+	fs.BoolVar(&s.usageContext, "usage-context", s.usageContext, "only report multiplications of durations whose result is used as a duration: passed to a duration parameter such as time.Sleep's, returned or stored as a duration, directly or through local variables")
+	fs.Var(&s.flagPackages, "flag-packages", "comma-separated import paths of the flag packages whose Duration definitions the flag-default rule checks")
+	fs.Var(&s.unitAPIs, "unit-apis", "comma-separated functions whose integer parameters expect a unit for the unit-args rule, e.g. example.com/thirdparty.SetTimeout=ms (units: ns, us, ms, s, m, h)")
+	fs.Var(&s.parseFuncs, "parse-funcs", "comma-separated functions returning durations parsed from strings for the parsed-rescale rule, identified by their package path and name, or receiver type and name, e.g. github.com/spf13/viper.Viper.GetDuration")
+	fs.Var(&s.profileNames, "profiles", "comma-separated third-party profiles whose duration wrapper types the wrapper-init rule checks: kubernetes (metav1.Duration)")
+	fs.StringVar(&s.nolintMode, "nolint", s.nolintMode, "handling of //nolint:durationcheck directives: off (golangci-lint applies them), respect (suppress findings) or directive (like //durationcheck:ignore)")
+	fs.StringVar(&s.minConfidence, "min-confidence", s.minConfidence, "least confidence of the rules run: certain skips the heuristic rules, e.g. names, whose findings are only probable")
+	fs.BoolVar(&s.reportIncomplete, "report-incomplete", s.reportIncomplete, "report the expressions that could not be analyzed, e.g. because of missing type information")
+	fs.StringVar(&s.whyFormat, "why", s.whyFormat, "write to stderr why the operands of reported multiplications carry a unit, as text, json or dot")
+	fs.BoolVar(&s.debug, "debug", s.debug, "write to stderr how each operand of every multiplication of durations was classified, reported or not, to triage false positives")
+}
+
+// validate checks the settings that options and flags can't check when they are set
+func (s *settings) validate() error {
+	switch s.nolintMode {
+	case nolintOff, nolintRespect, nolintDirective:
+	default:
+		return fmt.Errorf("invalid -nolint mode %q, expected off, respect or directive", s.nolintMode)
+	}
+
+	switch s.whyFormat {
+	case whyOff, whyText, whyJSON, whyDOT:
+	default:
+		return fmt.Errorf("invalid -why format %q, expected text, json or dot", s.whyFormat)
+	}
+
+	switch s.minConfidence {
+	case ConfidenceCertain, ConfidenceProbable:
+	default:
+		return fmt.Errorf("invalid -min-confidence %q, expected certain or probable", s.minConfidence)
+	}
+
+	if s.testsOnly && s.skipTests {
+		return fmt.Errorf("-tests-only and -skip-tests are mutually exclusive")
+	}
+
+	if err := validateRules(s.rules); err != nil {
+		return err
+	}
+
+	for _, check := range s.checks {
+		if code := strings.TrimPrefix(check, "-"); code != checkAll && lookupRule(code) == nil {
+			return fmt.Errorf("unknown rule %q in -checks", code)
+		}
+	}
+
+	for _, patterns := range [][]string{s.excluded, s.skipDirs, s.skipFiles} {
+		for _, pattern := range patterns {
+			if err := pathmatch.Validate(pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range s.durationTypes {
+		if err := validateDurationType(name); err != nil {
+			return fmt.Errorf("-duration-types: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateDurationType checks that an additional duration type has a package path and a name
+func validateDurationType(name string) error {
+	if i := strings.LastIndex(name, "."); i <= 0 || i == len(name)-1 {
+		return fmt.Errorf("invalid duration type %q, expected a package path and a type name, e.g. example.com/model.Duration", name)
+	}
+
+	return nil
+}
+
+// loadConfig returns the configuration set by WithConfig, validated once, or loads the configuration file, if any
+func (s *settings) loadConfig() (*Config, error) {
+	if s.config != nil {
+		s.configOnce.Do(func() {
+			if err := s.config.prepare("."); err != nil {
+				s.configErr = fmt.Errorf("invalid config: %w", err)
+			}
+		})
+		return s.config, s.configErr
+	}
+
+	if s.configFile == "" {
+		return nil, nil
+	}
+
+	return LoadConfig(s.configFile)
+}
+
+// confident returns true if the confidence of the rule reaches -min-confidence
+func (s *settings) confident(r *rule) bool {
+	return !r.probable || s.minConfidence == ConfidenceProbable
+}
+
+// enabledByDefault returns true if the rule is enabled when the configuration doesn't mention it
+func (s *settings) enabledByDefault(r *rule) bool {
+	return s.selected(r.code, !r.optIn || *s.optIn[r.code] || contains(s.rules, r.code))
+}
+
+// checkAll selects every rule in -checks
+const checkAll = "all"
+
+// selected applies -checks to a rule. The rules it lists replace the default ones, unless it only lists disabled
+// rules, and later entries override earlier ones.
+func (s *settings) selected(code string, enabled bool) bool {
+	for _, check := range s.checks {
+		if !strings.HasPrefix(check, "-") {
+			enabled = false
+			break
+		}
+	}
+
+	for _, check := range s.checks {
+		switch check {
+		case checkAll, code:
+			enabled = true
+		case "-" + checkAll, "-" + code:
+			enabled = false
+		}
+	}
+
+	return enabled
+}
+
+// excludedFile returns true if the file matches a pattern of WithExcludedPaths or -skip-files, or is below a directory
+// matching a pattern of -skip-dirs
+func (s *settings) excludedFile(filename string) bool {
+	for _, patterns := range [][]string{s.excluded, s.skipFiles} {
+		for _, pattern := range patterns {
+			if pathmatch.Match(pattern, filename) {
+				return true
+			}
+		}
+	}
+
+	for _, pattern := range s.skipDirs {
+		if pathmatch.Match(strings.TrimSuffix(pattern, "/")+"/", filename) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// An Option configures an analyzer created by NewAnalyzer or NewStandaloneAnalyzer. The flags of the analyzer can
+// still override it.
+type Option func(*settings)
+
+// WithRules enables opt-in rules, given by their codes.
+func WithRules(codes ...string) Option {
+	return func(s *settings) {
+		s.rules = append(s.rules, codes...)
+	}
+}
+
+// WithChecks selects the rules to run like the -checks flag, e.g. `WithChecks("all", "-names")`.
+func WithChecks(checks ...string) Option {
+	return func(s *settings) {
+		s.checks = checks
+	}
+}
+
+// WithConfig sets the configuration, like a configuration file would. Paths of the configuration, such as the
+// message catalog, are relative to the working directory.
+func WithConfig(cfg *Config) Option {
+	return func(s *settings) {
+		s.config = cfg
+	}
+}
+
+// WithConfigFile sets the path of the configuration file.
+func WithConfigFile(filename string) Option {
+	return func(s *settings) {
+		s.configFile = filename
+	}
+}
+
+// WithExcludedPaths excludes the files matching the path patterns from the analysis. Patterns have the syntax of the
+// paths of the configuration exemptions.
+func WithExcludedPaths(patterns ...string) Option {
+	return func(s *settings) {
+		s.excluded = append(s.excluded, patterns...)
+	}
+}
+
+// WithSkipDirs excludes the files below the directories matching the path patterns from the analysis, like
+// -skip-dirs.
+func WithSkipDirs(patterns ...string) Option {
+	return func(s *settings) {
+		s.skipDirs = append(s.skipDirs, patterns...)
+	}
+}
+
+// WithTestsOnly restricts the analysis to _test.go files.
+func WithTestsOnly() Option {
+	return func(s *settings) {
+		s.testsOnly = true
+	}
+}
+
+// WithSkipTests excludes the _test.go files from the analysis.
+func WithSkipTests() Option {
+	return func(s *settings) {
+		s.skipTests = true
+	}
+}
+
+// WithSkipGenerated skips the generated files.
+func WithSkipGenerated() Option {
+	return func(s *settings) {
+		s.skipGenerated = true
+	}
+}
+
+// WithUnscaledThreshold sets the largest bare integer constant accepted where a duration is expected.
+func WithUnscaledThreshold(n int) Option {
+	return func(s *settings) {
+		s.unscaledThreshold = n
+	}
+}
+
+// WithMaxExprLen sets the maximum length of the expressions quoted in diagnostic messages, 0 meaning no limit.
+func WithMaxExprLen(n int) Option {
+	return func(s *settings) {
+		s.maxExprLen = n
+	}
+}
+
+// WithMaxCountConst treats the constants of type time.Duration whose value is at most n as counts in multiplications.
+func WithMaxCountConst(n int) Option {
+	return func(s *settings) {
+		s.maxCountConst = n
+	}
+}
+
+// WithDurationTypes checks the types, identified by their package path and name, like time.Duration.
+func WithDurationTypes(names ...string) Option {
+	return func(s *settings) {
+		s.durationTypes = names
+	}
+}
+
+// WithAcceptNames treats the duration variables and fields whose name contains one of the words as counts in
+// multiplications.
+func WithAcceptNames(words ...string) Option {
+	return func(s *settings) {
+		s.acceptNames = words
+	}
+}
+
+// WithExcludedExprs suppresses the findings whose expressions match one of the patterns like -exclude-expr, e.g.
+// `WithExcludedExprs("jitter \\* spread@^Backoff$")`.
+func WithExcludedExprs(patterns ...string) Option {
+	return func(s *settings) {
+		s.excludeExprs = append(s.excludeExprs, patterns...)
+	}
+}
+
+// WithDataflow traces the operands of multiplications through local variables to classify them.
+func WithDataflow(enabled bool) Option {
+	return func(s *settings) {
+		s.dataflow = enabled
+	}
+}
+
+// WithStrict reports every multiplication of two durations, even when one of them is a count converted to a duration.
+func WithStrict(enabled bool) Option {
+	return func(s *settings) {
+		s.strict = enabled
+	}
+}
+
+// WithFlagPackages sets the import paths of the flag packages whose Duration definitions the flag-default rule
+// checks.
+func WithFlagPackages(paths ...string) Option {
+	return func(s *settings) {
+		s.flagPackages = paths
+	}
+}
+
+// WithUnitAPIs sets the functions whose integer parameters expect a unit, e.g.
+// `example.com/thirdparty.SetTimeout=ms`, for the unit-args rule.
+func WithUnitAPIs(entries ...string) Option {
+	return func(s *settings) {
+		s.unitAPIs = entries
+	}
+}
+
+// WithParseFuncs sets the functions returning durations parsed from strings, e.g.
+// `github.com/spf13/viper.GetDuration`, for the parsed-rescale rule.
+func WithParseFuncs(funcs ...string) Option {
+	return func(s *settings) {
+		s.parseFuncs = funcs
+	}
+}
+
+// WithProfiles sets the third-party profiles whose duration wrapper types the wrapper-init rule checks.
+func WithProfiles(names ...string) Option {
+	return func(s *settings) {
+		s.profileNames = names
+	}
+}
+
+// WithNolintMode sets the handling of //nolint:durationcheck directives: off, respect or directive.
+func WithNolintMode(mode string) Option {
+	return func(s *settings) {
+		s.nolintMode = mode
+	}
+}
+
+// WithUsageContext only reports the multiplications of durations whose result is used as a duration, like
+// -usage-context.
+func WithUsageContext() Option {
+	return func(s *settings) {
+		s.usageContext = true
+	}
+}
+
+// WithMinConfidence sets the least confidence of the rules run, certain or probable, see RuleConfidence.
+func WithMinConfidence(level string) Option {
+	return func(s *settings) {
+		s.minConfidence = level
+	}
+}
+
+// WithTrainer makes the analyzer record its findings in t instead of reporting them, see Trainer.
+func WithTrainer(t *Trainer) Option {
+	return func(s *settings) {
+		s.trainer = t
+	}
+}
+
+// NewAnalyzer returns an analyzer performing the checks of Analyzer with its own settings, for embedders configuring
+// it in Go code. Invalid options fail the analysis of each package.
+func NewAnalyzer(opts ...Option) *analysis.Analyzer {
+	return newAnalyzer(applyOptions(opts))
+}
+
+// NewStandaloneAnalyzer is like NewAnalyzer but returns an analyzer performing the checks of StandaloneAnalyzer.
+func NewStandaloneAnalyzer(opts ...Option) *analysis.Analyzer {
+	return newStandaloneAnalyzer(applyOptions(opts))
+}
+
+func applyOptions(opts []Option) *settings {
+	s := newSettings()
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func newAnalyzer(s *settings) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name: "durationcheck",
+		Doc:  "check for two durations multiplied together",
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return check(pass, s, nil, pass.ResultOf[inspect.Analyzer].(*inspector.Inspector),
+				pass.ResultOf[timeoutFactsAnalyzer].(timeoutParams), pass.ResultOf[resultFactsAnalyzer].(map[*types.Func]durationexpr.Kind),
+				pass.ResultOf[productFactsAnalyzer].(paramProducts))
+		},
+		Requires:   []*analysis.Analyzer{inspect.Analyzer, timeoutFactsAnalyzer, resultFactsAnalyzer, productFactsAnalyzer},
+		ResultType: resultType,
+	}
+	s.register(&a.Flags)
+
+	return a
+}
+
+func newStandaloneAnalyzer(s *settings) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name: "durationcheck",
+		Doc:  "check for two durations multiplied together",
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return check(pass, s, nil, nil, nil, nil, nil)
+		},
+		ResultType: resultType,
+	}
+	s.register(&a.Flags)
+
+	return a
+}
@@ -0,0 +1,241 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// paramProductsFact lists the pairs of duration parameters an exported function multiplies together, e.g. a and b of
+// `func Scale(a, b time.Duration) time.Duration { return a * b }`, so that the packages calling it can report the
+// calls passing two durations carrying a unit
+type paramProductsFact struct {
+	Products []paramProduct
+}
+
+// paramProduct is a pair of duration parameters multiplied together, identified by their indices
+type paramProduct struct {
+	X, Y int
+}
+
+func (*paramProductsFact) AFact() {}
+
+func (f *paramProductsFact) String() string {
+	pairs := make([]string, len(f.Products))
+	for i, p := range f.Products {
+		pairs[i] = fmt.Sprintf("%d*%d", p.X, p.Y)
+	}
+
+	return fmt.Sprintf("paramProducts(%s)", strings.Join(pairs, ", "))
+}
+
+// productFactsAnalyzer exports a paramProductsFact for the exported functions multiplying duration parameters
+// together, directly or by passing them to another such function, and returns those of the functions of the package
+// and of the functions it uses from other packages. Like timeoutFactsAnalyzer, it's separate from Analyzer to keep the
+// pass over the dependencies cheap.
+var productFactsAnalyzer = &analysis.Analyzer{
+	Name:       "durationcheckproducts",
+	Doc:        "export the duration parameters multiplied together by exported functions",
+	Run:        runProductFacts,
+	FactTypes:  []analysis.Fact{new(paramProductsFact)},
+	ResultType: reflect.TypeOf(paramProducts(nil)),
+
+	// best effort on packages with errors, like timeoutFactsAnalyzer
+	RunDespiteErrors: true,
+}
+
+// paramProducts maps functions to the pairs of duration parameters they multiply together
+type paramProducts map[*types.Func][]paramProduct
+
+func runProductFacts(pass *analysis.Pass) (interface{}, error) {
+	if pass.TypesInfo == nil {
+		return paramProducts(nil), nil
+	}
+
+	products := paramProducts{}
+	for _, obj := range pass.TypesInfo.Uses {
+		fn, ok := obj.(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+			continue
+		}
+
+		var fact paramProductsFact
+		if pass.ImportObjectFact(fn.Origin(), &fact) {
+			products[fn.Origin()] = fact.Products
+		}
+	}
+
+	for decl, found := range findParamProducts(pass.TypesInfo, pass.Files, products) {
+		if decl.Name.IsExported() && isExportedReceiver(decl) {
+			pass.ExportObjectFact(pass.TypesInfo.Defs[decl.Name].(*types.Func), &paramProductsFact{Products: found})
+		}
+	}
+
+	return products, nil
+}
+
+// findParamProducts adds to products the pairs of duration parameters multiplied together by the functions of the
+// files, and returns their declarations. Like classifyResults, the functions are searched until none changes, since a
+// function may pass its parameters to another one declared after it.
+func findParamProducts(info *types.Info, files []*ast.File, products paramProducts) map[*ast.FuncDecl][]paramProduct {
+	var decls []*ast.FuncDecl
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
+				if _, ok := info.Defs[decl.Name].(*types.Func); ok {
+					decls = append(decls, decl)
+				}
+			}
+		}
+	}
+
+	found := map[*ast.FuncDecl][]paramProduct{}
+	for range len(decls) + 1 {
+		changed := false
+		for _, decl := range decls {
+			pairs := declParamProducts(info, decl, products)
+			if len(pairs) == len(found[decl]) {
+				continue
+			}
+
+			found[decl] = pairs
+			products[info.Defs[decl.Name].(*types.Func)] = pairs
+			changed = true
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	return found
+}
+
+// declParamProducts returns the pairs of duration parameters the function multiplies together, in `a * b`, `a *= b`
+// or by passing them to a function of products
+func declParamProducts(info *types.Info, decl *ast.FuncDecl, products paramProducts) []paramProduct {
+	params := map[types.Object]int{}
+	sig := info.Defs[decl.Name].(*types.Func).Signature()
+	for i := range sig.Params().Len() {
+		if p := sig.Params().At(i); durationexpr.IsDuration(p.Type()) {
+			params[p] = i
+		}
+	}
+
+	if len(params) < 2 {
+		return nil
+	}
+
+	// param returns the index of the duration parameter the expression is, -1 for other expressions
+	param := func(expr ast.Expr) int {
+		if ident, ok := ast.Unparen(expr).(*ast.Ident); ok {
+			if i, ok := params[info.Uses[ident]]; ok {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	var pairs []paramProduct
+	add := func(x, y int) {
+		if x < 0 || y < 0 || x == y {
+			return
+		}
+
+		p := paramProduct{X: min(x, y), Y: max(x, y)}
+		for _, q := range pairs {
+			if q == p {
+				return
+			}
+		}
+		pairs = append(pairs, p)
+	}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			if n.Op == token.MUL {
+				add(param(n.X), param(n.Y))
+			}
+		case *ast.AssignStmt:
+			if n.Tok == token.MUL_ASSIGN && len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+				add(param(n.Lhs[0]), param(n.Rhs[0]))
+			}
+		case *ast.CallExpr:
+			if fn := calledFunc(info, n); fn != nil {
+				for _, p := range products[fn.Origin()] {
+					if p.Y < len(n.Args) {
+						add(param(n.Args[p.X]), param(n.Args[p.Y]))
+					}
+				}
+			}
+		}
+
+		return true
+	})
+
+	return pairs
+}
+
+// checkProductCall reports calls to functions multiplying their duration parameters together that pass two durations
+// carrying a unit, e.g. `scale(timeout, time.Second)`, as listed by productFactsAnalyzer. Functions passing their own
+// parameters multiply them as well, and are reported at their calls instead. StandaloneAnalyzer only knows about the
+// functions of the package.
+func (c *checker) checkProductCall(cur inspector.Cursor, call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return
+	}
+
+	name, sig := c.enclosingFunc(cur)
+
+	params := fn.Signature().Params()
+	for _, p := range c.products[fn.Origin()] {
+		if p.Y >= len(call.Args) || p.Y >= params.Len() {
+			continue
+		}
+
+		x, y := call.Args[p.X], call.Args[p.Y]
+		if !c.carriesUnit(x) || !c.carriesUnit(y) || name != "" && isParam(c.pass.TypesInfo, sig, x) && isParam(c.pass.TypesInfo, sig, y) {
+			continue
+		}
+
+		c.reportf(RuleMulCalls, call, "Multiplication of durations in call to `%s`: `%s` and `%s` carry units and are multiplied as its parameters `%s` and `%s`",
+			fn.Name(), c.formatExpr(x), c.formatExpr(y), params.At(p.X).Name(), params.At(p.Y).Name())
+	}
+}
+
+// carriesUnit returns true if the argument is a duration carrying a unit, as the operand of a multiplication
+func (c *checker) carriesUnit(arg ast.Expr) bool {
+	tv, ok := c.pass.TypesInfo.Types[arg]
+	if !ok || !c.classifier.IsDuration(tv.Type) {
+		return false
+	}
+
+	kind, _ := c.classify(nil, arg, tv)
+	return kind == durationexpr.Unit
+}
+
+// isParam returns true if the expression is a parameter of the signature
+func isParam(info *types.Info, sig *types.Signature, expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	for i := range sig.Params().Len() {
+		if info.Uses[ident] == sig.Params().At(i) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// durationWords are name components that indicate a value is a duration
+var durationWords = map[string]bool{
+	"timeout":  true,
+	"ttl":      true,
+	"interval": true,
+	"delay":    true,
+	"deadline": true,
+	"backoff":  true,
+	"period":   true,
+	"duration": true,
+	"wait":     true,
+	"sleep":    true,
+	"elapsed":  true,
+	"jitter":   true,
+	"expiry":   true,
+}
+
+// checkIntegerParams reports integer parameters of exported functions whose names indicate they are durations,
+// e.g. `func Dial(addr string, timeoutMs int)`; callers are left doing the unit math
+func (c *checker) checkIntegerParams(decl *ast.FuncDecl) {
+	if !decl.Name.IsExported() || !isExportedReceiver(decl) {
+		return
+	}
+
+	for _, field := range decl.Type.Params.List {
+		t := c.pass.TypesInfo.TypeOf(field.Type)
+		if !isInteger(t) || c.classifier.IsDuration(t) {
+			continue
+		}
+
+		for _, name := range field.Names {
+			if isDurationName(name.Name) {
+				c.reportf(RuleIntParams, name, "Integer parameter `%s` of exported function `%s` looks like a duration: use time.Duration",
+					name.Name, decl.Name.Name)
+			}
+		}
+	}
+}
+
+// isExportedReceiver returns true if the declaration is a function or a method of an exported type
+func isExportedReceiver(decl *ast.FuncDecl) bool {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return true
+	}
+
+	return ast.IsExported(recvTypeName(decl))
+}
+
+// recvTypeName returns the name of the receiver type of a method, or an empty string for functions
+func recvTypeName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return ""
+	}
+
+	typ := decl.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// isDurationName returns true if any word of the name is a duration word
+func isDurationName(name string) bool {
+	for _, word := range splitWords(name) {
+		if durationWords[word] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isInteger returns true if the underlying type of t is an integer type
+func isInteger(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsInteger != 0
+}
@@ -0,0 +1,220 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// parsedFlags are the functions and methods of the flag packages returning or binding durations parsed from the
+// command line
+var parsedFlags = map[string]bool{
+	"Duration":     true,
+	"DurationP":    true,
+	"DurationVar":  true,
+	"DurationVarP": true,
+	"GetDuration":  true,
+}
+
+// checkParsedRescale reports durations parsed from strings multiplied by a unit, e.g. `d * time.Second` with d
+// returned by time.ParseDuration, bound to a duration flag or returned by a function of -parse-funcs: "30s" is
+// already 30 seconds, and scaling it again makes it 30 billion seconds.
+func (c *checker) checkParsedRescale(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL || !isOutermostMultiplication(cur) {
+		return
+	}
+
+	operands := multiplicationOperands(expr)
+	parsed, source, unit, ok := c.parsedRescale(operands)
+	if !ok {
+		return
+	}
+
+	var fixes []analysis.SuggestedFix
+	if len(operands) == 2 {
+		fixes = c.replacementFix("Drop the unit", expr, formatNode(parsed))
+	}
+
+	c.reportFixf(RuleParsedRescale, expr, fixes, "Duration `%s` parsed by %s already carries a unit: multiplying it by %s rescales it",
+		c.formatExpr(parsed), source, formatNode(unit))
+}
+
+// checkParsedRescaleAssignment reports `d *= time.Second` with d parsed from a string
+func (c *checker) checkParsedRescaleAssignment(stmt *ast.AssignStmt) {
+	if stmt.Tok != token.MUL_ASSIGN || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return
+	}
+
+	if parsed, source, unit, ok := c.parsedRescale([]ast.Expr{stmt.Lhs[0], stmt.Rhs[0]}); ok && parsed == stmt.Lhs[0] {
+		c.reportf(RuleParsedRescale, stmt, "Duration `%s` parsed by %s already carries a unit: multiplying it by %s rescales it",
+			c.formatExpr(parsed), source, formatNode(unit))
+	}
+}
+
+// parsedRescale returns the operand of a product that is a parsed duration, the function that parsed it and the unit
+// constant scaling it, if any. time.Nanosecond is left to redundant-unit.
+func (c *checker) parsedRescale(operands []ast.Expr) (parsed ast.Expr, source string, unit ast.Expr, ok bool) {
+	for _, operand := range operands {
+		if isUnitConstant(c.pass, operand) {
+			if unit == nil && !isNanosecond(c.pass, operand) {
+				unit = operand
+			}
+			continue
+		}
+
+		if parsed == nil {
+			if s, ok := c.parsedSource(operand); ok {
+				parsed, source = operand, s
+			}
+		}
+	}
+
+	return parsed, source, unit, parsed != nil && unit != nil
+}
+
+// parsedSource returns the function that parsed the duration of the expression: a call to it, a variable assigned its
+// result or bound by it, or a dereference of such a pointer, e.g. `*timeout` for `timeout := flag.Duration(...)`
+func (c *checker) parsedSource(expr ast.Expr) (string, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		if c.classifier.IsConversion(e) && c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(e.Args[0])) {
+			return c.parsedSource(e.Args[0])
+		}
+
+		if fn := c.calledFunc(e); fn != nil && c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(e)) {
+			return c.parseFunc(fn)
+		}
+	case *ast.StarExpr:
+		if call, ok := ast.Unparen(e.X).(*ast.CallExpr); ok {
+			if fn := c.calledFunc(call); fn != nil {
+				return c.parseFunc(fn)
+			}
+		}
+
+		return c.parsedSource(e.X)
+	case *ast.Ident, *ast.SelectorExpr:
+		if obj := c.pass.TypesInfo.ObjectOf(selectedIdent(e)); obj != nil {
+			source, ok := c.parsedVarsOf()[obj]
+			return source, ok
+		}
+	}
+
+	return "", false
+}
+
+// parseFunc returns the name of the function if it parses durations from strings
+func (c *checker) parseFunc(fn *types.Func) (string, bool) {
+	if fn.Pkg() == nil {
+		return "", false
+	}
+
+	name := fn.Pkg().Name() + "." + fn.Name()
+	switch {
+	case fn.Pkg().Path() == "time" && fn.Name() == "ParseDuration":
+	case contains(c.settings.flagPackages, fn.Pkg().Path()) && parsedFlags[fn.Name()]:
+	case contains(c.settings.parseFuncs, funcKey(fn)):
+	default:
+		return "", false
+	}
+
+	return name, true
+}
+
+// parsedVarsOf returns the variables and fields of the package holding parsed durations, or pointers to them, and the
+// function that parsed them, indexing them on first use. Those also assigned other values aren't included.
+func (c *checker) parsedVarsOf() map[types.Object]string {
+	if c.parsedVars != nil {
+		return c.parsedVars
+	}
+
+	c.parsedVars = map[types.Object]string{}
+	assigned := map[types.Object]bool{}
+
+	store := func(lhs ast.Expr, rhs ast.Expr) {
+		obj := c.pass.TypesInfo.ObjectOf(selectedIdent(ast.Unparen(lhs)))
+		if obj == nil {
+			return
+		}
+
+		if call, ok := ast.Unparen(rhs).(*ast.CallExpr); ok {
+			if fn := c.calledFunc(call); fn != nil {
+				if source, ok := c.parseFunc(fn); ok {
+					c.parsedVars[obj] = source
+					return
+				}
+			}
+		}
+		assigned[obj] = true
+	}
+
+	for _, file := range c.pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if n.Tok != token.ASSIGN && n.Tok != token.DEFINE {
+					return true
+				}
+
+				if len(n.Rhs) == 1 && len(n.Lhs) > 1 {
+					// `d, err := time.ParseDuration(s)`
+					store(n.Lhs[0], n.Rhs[0])
+					return true
+				}
+
+				for i := range n.Lhs {
+					if i < len(n.Rhs) {
+						store(n.Lhs[i], n.Rhs[i])
+					}
+				}
+			case *ast.ValueSpec:
+				if len(n.Values) == 1 && len(n.Names) > 1 {
+					store(n.Names[0], n.Values[0])
+					return true
+				}
+
+				for i := range n.Names {
+					if i < len(n.Values) {
+						store(n.Names[i], n.Values[i])
+					}
+				}
+			case *ast.CallExpr:
+				// `flag.DurationVar(&timeout, ...)`
+				fn := c.calledFunc(n)
+				if fn == nil || len(n.Args) == 0 {
+					return true
+				}
+
+				if addr, ok := ast.Unparen(n.Args[0]).(*ast.UnaryExpr); ok && addr.Op == token.AND {
+					if source, ok := c.parseFunc(fn); ok {
+						if obj := c.pass.TypesInfo.ObjectOf(selectedIdent(ast.Unparen(addr.X))); obj != nil {
+							c.parsedVars[obj] = source
+						}
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	for obj := range assigned {
+		delete(c.parsedVars, obj)
+	}
+
+	return c.parsedVars
+}
+
+// selectedIdent returns the identifier of a variable or of the field of a selector, nil for other expressions
+func selectedIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// integerVerbs are the verbs formatting an integer as a number
+const integerVerbs = "bdoOxX"
+
+// printfVerb is a verb of a format string and the argument it formats
+type printfVerb struct {
+	verb rune
+	// offset is the offset of the verb in the format string
+	offset int
+	arg    int
+}
+
+// checkPrintfArguments reports durations formatted with an integer verb by printf-like functions, e.g.
+// `fmt.Sprintf("%d ms", d)`, which prints a count of nanoseconds. The text following the verb names the intended
+// unit, if any.
+func (c *checker) checkPrintfArguments(call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return
+	}
+
+	index, ok := printfFormat(fn)
+	if !ok || index >= len(call.Args) || call.Ellipsis.IsValid() {
+		return
+	}
+
+	value := c.pass.TypesInfo.Types[call.Args[index]].Value
+	if value == nil || value.Kind() != constant.String {
+		return
+	}
+
+	format := constant.StringVal(value)
+	for _, v := range parsePrintfVerbs(format) {
+		if !strings.ContainsRune(integerVerbs, v.verb) || index+1+v.arg >= len(call.Args) {
+			continue
+		}
+
+		arg := call.Args[index+1+v.arg]
+		if !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(arg)) {
+			continue
+		}
+
+		unit := followingUnit(format[v.offset+1:])
+		if unit == "Nanosecond" {
+			continue
+		}
+
+		if unit == "" {
+			c.reportFixf(RulePrintf, arg, c.verbFixes(call.Args[index], v),
+				"Duration `%s` formatted with `%%%c` prints a count of nanoseconds: use `%%v`", c.formatExpr(arg), v.verb)
+			continue
+		}
+
+		accessor := unitAccessor(arg, unit)
+		c.reportFixf(RulePrintf, arg, []analysis.SuggestedFix{{
+			Message:   "Format the count of " + strings.ToLower(unit) + "s",
+			TextEdits: []analysis.TextEdit{{Pos: arg.Pos(), End: arg.End(), NewText: []byte(accessor)}},
+		}}, "Duration `%s` formatted with `%%%c` prints a count of nanoseconds, not of %ss: use `%s`",
+			c.formatExpr(arg), v.verb, strings.ToLower(unit), accessor)
+	}
+}
+
+// printfFormat returns the index of the format parameter of a printf-like function, one whose `format string`
+// parameter precedes its final `...any` one like fmt.Printf, log.Printf, testing.T.Errorf and most logging wrappers
+func printfFormat(fn *types.Func) (int, bool) {
+	sig := fn.Type().(*types.Signature)
+	params := sig.Params()
+	if !sig.Variadic() || params.Len() < 2 {
+		return 0, false
+	}
+
+	elem := params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+	if iface, ok := elem.Underlying().(*types.Interface); !ok || !iface.Empty() {
+		return 0, false
+	}
+
+	format := params.At(params.Len() - 2)
+	if basic, ok := format.Type().(*types.Basic); !ok || basic.Kind() != types.String {
+		return 0, false
+	}
+
+	return params.Len() - 2, format.Name() == "format"
+}
+
+// parsePrintfVerbs returns the verbs of a format string. It returns none for a format string using explicit argument
+// indexes, e.g. `%[2]d`.
+func parsePrintfVerbs(format string) []printfVerb {
+	var verbs []printfVerb
+
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		for i++; i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0; i++ {
+		}
+
+		// the width and the precision, which consume an argument when they are a star
+		for i < len(format) && (format[i] >= '0' && format[i] <= '9' || format[i] == '.' || format[i] == '*') {
+			if format[i] == '*' {
+				arg++
+			}
+			i++
+		}
+
+		if i >= len(format) {
+			break
+		}
+
+		if format[i] == '[' {
+			return nil
+		}
+
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		if verb != '%' {
+			verbs = append(verbs, printfVerb{verb: verb, offset: i, arg: arg})
+			arg++
+		}
+		i += size - 1
+	}
+
+	return verbs
+}
+
+// followingUnit returns the unit named by the word following a verb, e.g. `ms` in `%d ms` or `seconds` in `%ds`
+func followingUnit(text string) string {
+	text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	end := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end < 0 {
+		end = len(text)
+	}
+
+	word := strings.ToLower(text[:end])
+	if unit, ok := unitWords[word]; ok {
+		return unit
+	}
+
+	return unitAbbreviations[word]
+}
+
+// unitAccessor returns the call giving the count of the unit of a duration, converted to an integer for the float
+// accessors
+func unitAccessor(d ast.Expr, unit string) string {
+	accessor := formatOperand(d) + "." + unit + "s()"
+	switch unit {
+	case "Microsecond", "Millisecond":
+		return accessor
+	default:
+		return "int64(" + accessor + ")"
+	}
+}
+
+// verbFixes returns the rewrite of the verb to %v, for formats written as a single string literal
+func (c *checker) verbFixes(format ast.Expr, v printfVerb) []analysis.SuggestedFix {
+	lit, ok := ast.Unparen(format).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING || strings.Contains(lit.Value, `\`) {
+		return nil
+	}
+
+	pos := lit.Pos() + token.Pos(1+v.offset)
+	return []analysis.SuggestedFix{{
+		Message:   "Format with %v",
+		TextEdits: []analysis.TextEdit{{Pos: pos, End: pos + 1, NewText: []byte("v")}},
+	}}
+}
@@ -0,0 +1,101 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+)
+
+// checkQuotientArgument reports quotients of durations passed to duration parameters, e.g.
+// `time.Sleep(total / interval)`, directly or through a local variable holding one. The quotient of two durations is
+// a count typed as a duration, so the call receives a few nanoseconds.
+func (c *checker) checkQuotientArgument(call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return
+	}
+
+	sig := fn.Type().(*types.Signature)
+	for i, arg := range call.Args {
+		if i >= sig.Params().Len() || sig.Variadic() && i == sig.Params().Len()-1 {
+			break
+		}
+
+		if !c.classifier.IsDuration(sig.Params().At(i).Type()) {
+			continue
+		}
+
+		c.reportQuotient(arg, "passed to "+funcKey(fn))
+	}
+}
+
+// checkQuotientField reports quotients of durations assigned to duration fields in composite literals, e.g.
+// `http.Client{Timeout: total / interval}`
+func (c *checker) checkQuotientField(lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		if field, ok := c.pass.TypesInfo.ObjectOf(key).(*types.Var); ok && field.IsField() && c.classifier.IsDuration(field.Type()) {
+			c.reportQuotient(kv.Value, "assigned to field "+key.Name)
+		}
+	}
+}
+
+// reportQuotient reports the value if it is a quotient of durations used where a duration is expected
+func (c *checker) reportQuotient(value ast.Expr, use string) {
+	quo, ok := c.durationQuotient(value)
+	if !ok {
+		return
+	}
+
+	if quo == ast.Unparen(value) {
+		c.reportf(RuleQuotient, value, "Quotient of durations `%s` is a count, not a duration: %s", c.formatExpr(quo), use)
+		return
+	}
+
+	c.reportf(RuleQuotient, value, "`%s` holds the quotient of durations `%s`, a count, not a duration: %s",
+		c.formatExpr(value), c.formatExpr(quo), use)
+}
+
+// durationQuotient returns the division of two durations carrying a unit the expression evaluates to, e.g.
+// `total / interval`, or the division a local variable is defined with and never assigned again
+func (c *checker) durationQuotient(expr ast.Expr) (*ast.BinaryExpr, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BinaryExpr:
+		if e.Op != token.QUO || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(e.X)) || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(e.Y)) {
+			return nil, false
+		}
+
+		// dividing by a count, e.g. `total / time.Duration(n)`, gives a duration
+		return e, c.classifier.Classify(e.X) == durationexpr.Unit && c.classifier.Classify(e.Y) == durationexpr.Unit
+	case *ast.Ident:
+		v, ok := c.pass.TypesInfo.Uses[e].(*types.Var)
+		if !ok || v.Pkg() != c.pass.Pkg || c.file == nil {
+			return nil, false
+		}
+
+		value, scope := c.definition(v)
+		if value == nil || c.reassigned(scope, v) {
+			return nil, false
+		}
+
+		quo, ok := ast.Unparen(value).(*ast.BinaryExpr)
+		if !ok {
+			return nil, false
+		}
+
+		return c.durationQuotient(quo)
+	default:
+		return nil, false
+	}
+}
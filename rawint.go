@@ -0,0 +1,135 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// maxCountComparison bounds the constants that integer conversions of durations are compared to as if they were
+// counts of seconds or milliseconds: a millisecond in nanoseconds
+const maxCountComparison = 1000000
+
+// checkRawIntComparison reports integer conversions of durations compared to small constants, e.g. `int64(d) > 30`,
+// which compares nanoseconds to what is likely a count of seconds or milliseconds
+func (c *checker) checkRawIntComparison(expr *ast.BinaryExpr) {
+	switch expr.Op {
+	case token.GTR, token.LSS, token.GEQ, token.LEQ, token.EQL, token.NEQ:
+	default:
+		return
+	}
+
+	conv, other := expr.X, expr.Y
+	if !c.isRawIntConversion(conv) {
+		conv, other = other, conv
+	}
+
+	value := c.pass.TypesInfo.Types[other].Value
+	if !c.isRawIntConversion(conv) || value == nil {
+		return
+	}
+
+	n, exact := constant.Int64Val(constant.ToInt(value))
+	if !exact || n <= int64(c.settings.unscaledThreshold) && n >= -int64(c.settings.unscaledThreshold) || n >= maxCountComparison || n <= -maxCountComparison {
+		return
+	}
+
+	call := ast.Unparen(conv).(*ast.CallExpr)
+	fixes := append(c.countFixes(call, "Second"), c.countFixes(call, "Millisecond")...)
+	c.reportFixf(RuleRawInt, call, fixes, "Duration `%s` converted to `%s` counts nanoseconds but is compared to `%s`: convert a count of seconds or milliseconds, e.g. `%s`",
+		c.formatExpr(call.Args[0]), formatNode(call.Fun), c.formatExpr(other), c.countExpr(call, "Second"))
+}
+
+// checkRawIntAssignment reports integer conversions of durations assigned to variables or fields named in a unit,
+// e.g. `cfg.TimeoutSeconds = int64(d)`
+func (c *checker) checkRawIntAssignment(stmt *ast.AssignStmt) {
+	if stmt.Tok != token.ASSIGN && stmt.Tok != token.DEFINE || len(stmt.Lhs) != len(stmt.Rhs) {
+		return
+	}
+
+	for i, lhs := range stmt.Lhs {
+		c.checkRawIntStore(identName(lhs), stmt.Rhs[i])
+	}
+}
+
+// checkRawIntDeclaration reports integer conversions of durations initializing variables named in a unit, e.g.
+// `var timeoutMs = int(d)`
+func (c *checker) checkRawIntDeclaration(spec *ast.ValueSpec) {
+	if len(spec.Names) != len(spec.Values) {
+		return
+	}
+
+	for i, name := range spec.Names {
+		c.checkRawIntStore(name.Name, spec.Values[i])
+	}
+}
+
+// checkRawIntField reports integer conversions of durations initializing fields named in a unit, e.g.
+// `Config{TimeoutSeconds: int(d)}`
+func (c *checker) checkRawIntField(lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			c.checkRawIntStore(identName(kv.Key), kv.Value)
+		}
+	}
+}
+
+// isRawIntConversion returns true if the expression converts a duration carrying a unit to an integer, unlike the
+// quotients of durations such as `int64(d / time.Second)`, which are counts
+func (c *checker) isRawIntConversion(expr ast.Expr) bool {
+	if !c.isDurationToIntConversion(expr) || c.pass.TypesInfo.Types[expr].Value != nil {
+		return false
+	}
+
+	quo, ok := ast.Unparen(ast.Unparen(expr).(*ast.CallExpr).Args[0]).(*ast.BinaryExpr)
+	return !ok || quo.Op != token.QUO || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(quo.Y))
+}
+
+// checkRawIntStore reports the value if it's an integer conversion of a duration stored under a name in a unit
+func (c *checker) checkRawIntStore(name string, value ast.Expr) {
+	unit := nameUnit(name)
+	if unit == "" || unit == "Nanosecond" || !c.isRawIntConversion(value) {
+		return
+	}
+
+	call := ast.Unparen(value).(*ast.CallExpr)
+	c.reportFixf(RuleRawInt, call, c.countFixes(call, unit), "Duration `%s` converted to `%s` counts nanoseconds but is stored in `%s`: use `%s`",
+		c.formatExpr(call.Args[0]), formatNode(call.Fun), name, c.countExpr(call, unit))
+}
+
+// countExpr returns the count of the unit of the duration converted by the call, with the accessor of the unit when
+// it has the type of the conversion, e.g. `d.Milliseconds()`, or dividing it by the unit, e.g. `int(d / time.Second)`
+func (c *checker) countExpr(call *ast.CallExpr, unit string) string {
+	d := call.Args[0]
+	if (unit == "Millisecond" || unit == "Microsecond") && types.Identical(c.pass.TypesInfo.TypeOf(call), types.Typ[types.Int64]) {
+		return formatOperand(d) + "." + unit + "s()"
+	}
+
+	qualifier, ok := c.timeQualifier()
+	if !ok {
+		qualifier = "time"
+	}
+
+	operand := formatNode(d)
+	if _, ok := ast.Unparen(d).(*ast.BinaryExpr); ok {
+		operand = "(" + operand + ")"
+	}
+
+	return formatNode(call.Fun) + "(" + operand + " / " + qualifier + "." + unit + ")"
+}
+
+// countFixes returns the rewrite of the conversion into the count of the unit, see countExpr
+func (c *checker) countFixes(call *ast.CallExpr, unit string) []analysis.SuggestedFix {
+	if _, ok := c.timeQualifier(); !ok {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Count " + strings.ToLower(unit) + "s",
+		TextEdits: []analysis.TextEdit{{Pos: call.Pos(), End: call.End(), NewText: []byte(c.countExpr(call, unit))}},
+	}}
+}
@@ -0,0 +1,120 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkRedundantUnit reports durations multiplied or divided by time.Nanosecond, e.g. `d * time.Nanosecond` or
+// `d / time.Nanosecond`, identities whose author believed d to be a raw count of nanoseconds. Converting a count,
+// e.g. `time.Duration(ns) * time.Nanosecond`, states the unit and isn't reported.
+func (c *checker) checkRedundantUnit(cur inspector.Cursor, expr *ast.BinaryExpr) {
+	d, ok := c.redundantUnitOperand(expr.X, expr.Op, expr.Y)
+	if !ok {
+		return
+	}
+
+	// `int64(d / time.Nanosecond)` is the accessor, converted again for the other integer types
+	if conversion, ok := c.integerConversion(cur); ok && expr.Op == token.QUO {
+		accessor := formatOperand(d) + ".Nanoseconds()"
+
+		var replaced ast.Node = expr
+		if types.Identical(c.pass.TypesInfo.TypeOf(conversion), types.Typ[types.Int64]) {
+			replaced = conversion
+		}
+
+		c.reportFixf(RuleRedundantUnit, conversion, c.replacementFix("Use the Nanoseconds accessor", replaced, accessor),
+			"Division of duration `%s` by time.Nanosecond is redundant: use `%s`", c.formatExpr(d), accessor)
+		return
+	}
+
+	c.reportFixf(RuleRedundantUnit, expr, c.replacementFix("Drop the redundant unit", expr, formatNode(d)),
+		"%s of duration `%s` by time.Nanosecond is redundant: `%s` already is a duration", operationName(expr.Op), c.formatExpr(d), c.formatExpr(d))
+}
+
+// checkRedundantUnitAssignment reports `d *= time.Nanosecond` and `d /= time.Nanosecond`
+func (c *checker) checkRedundantUnitAssignment(stmt *ast.AssignStmt) {
+	if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return
+	}
+
+	var op token.Token
+	switch stmt.Tok {
+	case token.MUL_ASSIGN:
+		op = token.MUL
+	case token.QUO_ASSIGN:
+		op = token.QUO
+	default:
+		return
+	}
+
+	if d, ok := c.redundantUnitOperand(stmt.Lhs[0], op, stmt.Rhs[0]); ok {
+		c.reportf(RuleRedundantUnit, stmt, "%s of duration `%s` by time.Nanosecond is redundant: `%s` already is a duration",
+			operationName(op), c.formatExpr(d), c.formatExpr(d))
+	}
+}
+
+// redundantUnitOperand returns the duration carrying a unit of `d * time.Nanosecond`, `time.Nanosecond * d` or
+// `d / time.Nanosecond`
+func (c *checker) redundantUnitOperand(x ast.Expr, op token.Token, y ast.Expr) (ast.Expr, bool) {
+	d := x
+	switch {
+	case op == token.MUL && isNanosecond(c.pass, x):
+		d = y
+	case (op == token.MUL || op == token.QUO) && isNanosecond(c.pass, y):
+	default:
+		return nil, false
+	}
+
+	if !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(d)) || isUnitConstant(c.pass, d) {
+		return nil, false
+	}
+
+	return d, c.classifier.Classify(d) == durationexpr.Unit
+}
+
+// integerConversion returns the conversion to an integer type the expression of the cursor is the argument of
+func (c *checker) integerConversion(cur inspector.Cursor) (*ast.CallExpr, bool) {
+	parent := cur.Parent()
+	for isParen(parent.Node()) {
+		parent = parent.Parent()
+	}
+
+	call, ok := parent.Node().(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, false
+	}
+
+	fun, ok := c.pass.TypesInfo.Types[call.Fun]
+	if !ok || !fun.IsType() || c.classifier.IsDuration(fun.Type) {
+		return nil, false
+	}
+
+	basic, ok := fun.Type.Underlying().(*types.Basic)
+	return call, ok && basic.Info()&types.IsInteger != 0
+}
+
+// replacementFix returns the suggested fix replacing the node with the text
+func (c *checker) replacementFix(message string, node ast.Node, text string) []analysis.SuggestedFix {
+	return []analysis.SuggestedFix{{
+		Message:   message,
+		TextEdits: []analysis.TextEdit{{Pos: node.Pos(), End: node.End(), NewText: []byte(text)}},
+	}}
+}
+
+func isNanosecond(pass *analysis.Pass, expr ast.Expr) bool {
+	return isUnitConstant(pass, expr) && ast.Unparen(expr).(*ast.SelectorExpr).Sel.Name == "Nanosecond"
+}
+
+func operationName(op token.Token) string {
+	if op == token.QUO {
+		return "Division"
+	}
+
+	return "Multiplication"
+}
@@ -0,0 +1,193 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// operandOrigins returns related information pointing at where the operands carrying a unit come from: the
+// declaration of a variable, the definition assigning it, or the signature of a function returning it. The objects of
+// the time package, such as time.Second, need no explanation.
+func (c *checker) operandOrigins(operands []ast.Expr) []analysis.RelatedInformation {
+	var related []analysis.RelatedInformation
+	seen := map[token.Pos]bool{}
+
+	for _, op := range operands {
+		pos, message, ok := c.operandOrigin(op)
+		if !ok || seen[pos] {
+			continue
+		}
+		seen[pos] = true
+
+		related = append(related, analysis.RelatedInformation{Pos: pos, Message: message})
+	}
+
+	return related
+}
+
+func (c *checker) operandOrigin(op ast.Expr) (token.Pos, string, bool) {
+	switch e := ast.Unparen(op).(type) {
+	case *ast.UnaryExpr:
+		return c.operandOrigin(e.X)
+	case *ast.Ident:
+		return c.objectOrigin(e)
+	case *ast.SelectorExpr:
+		return c.objectOrigin(e.Sel)
+	case *ast.StarExpr:
+		return c.operandOrigin(e.X)
+	case *ast.CallExpr:
+		// conversions keep the unit of their operand, e.g. `time.Duration(elapsed)`
+		if fun, ok := c.pass.TypesInfo.Types[e.Fun]; ok && fun.IsType() && len(e.Args) == 1 {
+			return c.operandOrigin(e.Args[0])
+		}
+
+		fn := c.calledFunc(e)
+		if fn == nil || !fn.Pos().IsValid() || isTimePackage(fn.Pkg()) {
+			return token.NoPos, "", false
+		}
+
+		return fn.Pos(), fmt.Sprintf(c.config.translate("`%s` returns a duration carrying a unit"), fn.Name()), true
+	default:
+		return token.NoPos, "", false
+	}
+}
+
+func (c *checker) objectOrigin(ident *ast.Ident) (token.Pos, string, bool) {
+	obj := c.pass.TypesInfo.ObjectOf(ident)
+	if obj == nil || !obj.Pos().IsValid() || isTimePackage(obj.Pkg()) {
+		return token.NoPos, "", false
+	}
+
+	if v, ok := obj.(*types.Var); ok && c.file != nil && obj.Pkg() == c.pass.Pkg {
+		path, _ := astutil.PathEnclosingInterval(c.file, v.Pos(), v.Pos())
+		if len(path) > 2 {
+			switch parent := path[1].(type) {
+			case *ast.AssignStmt:
+				// `elapsed := time.Since(start)`
+				if len(parent.Lhs) == len(parent.Rhs) {
+					for i, lhs := range parent.Lhs {
+						if lhs.Pos() == v.Pos() {
+							return v.Pos(), fmt.Sprintf(c.config.translate("`%s` is defined here as `%s`"), v.Name(), c.formatExpr(parent.Rhs[i])), true
+						}
+					}
+				}
+			case *ast.Field:
+				if c.isParamVar(v) {
+					return v.Pos(), fmt.Sprintf(c.config.translate("`%s` is a parameter of type %s"), v.Name(), v.Type()), true
+				}
+			}
+		}
+	}
+
+	return obj.Pos(), fmt.Sprintf(c.config.translate("`%s` is declared here with type %s"), obj.Name(), obj.Type()), true
+}
+
+func isTimePackage(pkg *types.Package) bool {
+	return pkg != nil && pkg.Path() == "time"
+}
+
+// suspectOperands describes the operands of a reported multiplication carrying a unit, with their type and where
+// their value comes from, e.g. ": left operand `timeout` (time.Duration, field) already carries a unit". The unit
+// constants of the time package are left out, as well as the side of the operands of chains. The message doesn't give
+// their positions, which would change the fingerprints of the findings when lines shift: the related information does.
+func (c *checker) suspectOperands(operands, units []ast.Expr) string {
+	var suspects []string
+	for _, op := range units {
+		if isUnitConstant(c.pass, op) {
+			continue
+		}
+
+		description := fmt.Sprintf("`%s` (%s", c.formatExpr(op), c.pass.TypesInfo.TypeOf(op))
+		if provenance := c.operandProvenance(op); provenance != "" {
+			description += ", " + provenance
+		}
+		description += ")"
+
+		if len(operands) == 2 {
+			side := c.config.translate("left operand %s")
+			if op == operands[1] {
+				side = c.config.translate("right operand %s")
+			}
+			description = fmt.Sprintf(side, description)
+		}
+
+		suspects = append(suspects, description)
+	}
+
+	switch len(suspects) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(c.config.translate(": %s already carries a unit"), suspects[0])
+	default:
+		return fmt.Sprintf(c.config.translate(": %s already carry units"), strings.Join(suspects, c.config.translate(" and ")))
+	}
+}
+
+// operandProvenance tells where the value of an operand comes from: the kind of variable it is or the function
+// returning it, or an empty string if it can't be told
+func (c *checker) operandProvenance(op ast.Expr) string {
+	switch e := ast.Unparen(op).(type) {
+	case *ast.UnaryExpr:
+		return c.operandProvenance(e.X)
+	case *ast.StarExpr:
+		return c.operandProvenance(e.X)
+	case *ast.Ident:
+		return c.objectProvenance(e)
+	case *ast.SelectorExpr:
+		return c.objectProvenance(e.Sel)
+	case *ast.CallExpr:
+		if fun, ok := c.pass.TypesInfo.Types[e.Fun]; ok && fun.IsType() && len(e.Args) == 1 {
+			return c.operandProvenance(e.Args[0])
+		}
+
+		fn := c.calledFunc(e)
+		if fn == nil || !fn.Pos().IsValid() {
+			return ""
+		}
+
+		return fmt.Sprintf(c.config.translate("returned by `%s`"), fn.Name())
+	default:
+		return ""
+	}
+}
+
+func (c *checker) objectProvenance(ident *ast.Ident) string {
+	obj, ok := c.pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || !obj.Pos().IsValid() || isTimePackage(obj.Pkg()) {
+		return ""
+	}
+
+	switch {
+	case obj.IsField():
+		return c.config.translate("field")
+	case obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope():
+		return c.config.translate("package variable")
+	case c.isParamVar(obj):
+		return c.config.translate("parameter")
+	default:
+		return c.config.translate("local variable")
+	}
+}
+
+// isParamVar returns true if the variable is a parameter or a result of a function declared in the file being checked
+func (c *checker) isParamVar(v *types.Var) bool {
+	if c.file == nil || v.Pkg() != c.pass.Pkg || v.IsField() {
+		return false
+	}
+
+	path, _ := astutil.PathEnclosingInterval(c.file, v.Pos(), v.Pos())
+	if len(path) <= 2 {
+		return false
+	}
+
+	_, isField := path[1].(*ast.Field)
+	_, isList := path[2].(*ast.FieldList)
+	return isField && isList
+}
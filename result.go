@@ -0,0 +1,52 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	"github.com/charithe/durationcheck/durationexpr"
+)
+
+// Result is the result of Analyzer and StandaloneAnalyzer: the findings they reported for the package, so that the
+// analyzers requiring them, or multichecker-based tools, can use the analysis without parsing the diagnostics.
+type Result struct {
+	Findings []Finding
+}
+
+// resultType is the ResultType of the analyzers
+var resultType = reflect.TypeOf((*Result)(nil))
+
+// A Finding is a reported diagnostic.
+type Finding struct {
+	// Rule is the code of the rule reporting the finding, e.g. mul.
+	Rule string
+	// Node is the reported expression or statement.
+	Node ast.Node
+	// Message is the message of the diagnostic, as reported after the middleware.
+	Message string
+	// Confidence is the confidence of the rule, ConfidenceCertain or ConfidenceProbable.
+	Confidence string
+	// Operands are the operands of a multiplication of durations, in source order, and nil for the other rules.
+	Operands []Operand
+}
+
+// An Operand is an operand of a multiplication of durations.
+type Operand struct {
+	Expr ast.Expr
+	Type types.Type
+	// Kind tells whether the operand carries a unit.
+	Kind durationexpr.Kind
+}
+
+// addFinding records a reported finding in the result
+func (c *checker) addFinding(rule string, node ast.Node, message string) {
+	c.result.Findings = append(c.result.Findings, Finding{Rule: rule, Node: node, Message: message, Confidence: RuleConfidence(rule)})
+}
+
+// setOperands sets the operands of the finding just reported for a multiplication, unless the middleware dropped it
+func (c *checker) setOperands(node ast.Node, operands []Operand) {
+	if n := len(c.result.Findings); n > 0 && c.result.Findings[n-1].Node == node {
+		c.result.Findings[n-1].Operands = operands
+	}
+}
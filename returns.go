@@ -0,0 +1,80 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// nanosecondWords are the last name components of functions stating that they return nanoseconds, e.g.
+// `TimeoutNanos`, like unitWords for the other units
+var nanosecondWords = map[string]bool{
+	"ns":          true,
+	"nsec":        true,
+	"nanos":       true,
+	"nanoseconds": true,
+}
+
+// checkReturnedInteger reports durations converted to integers in the return statements of functions declared to
+// return integers, e.g. `func Timeout() int64 { return int64(d) }`, which lose the unit at the API boundary. The
+// functions whose names state nanoseconds, e.g. `TimeoutNanos`, keep it, as do the unit accessors such as
+// d.Milliseconds(), which aren't conversions.
+func (c *checker) checkReturnedInteger(cur inspector.Cursor, ret *ast.ReturnStmt) {
+	name, sig := c.enclosingFunc(cur)
+	if sig == nil || sig.Results().Len() != len(ret.Results) {
+		return
+	}
+
+	if words := splitWords(name); len(words) > 0 && nanosecondWords[words[len(words)-1]] {
+		return
+	}
+
+	for i, result := range ret.Results {
+		if !isInteger(sig.Results().At(i).Type()) || !c.isDurationToIntConversion(result) {
+			continue
+		}
+
+		what := "the result"
+		if name != "" {
+			what = "the result of `" + name + "`"
+		}
+
+		c.reportf(RuleReturnInt, result, "Duration returned as %s loses its unit: declare %s as time.Duration",
+			sig.Results().At(i).Type(), what)
+	}
+}
+
+// enclosingFunc returns the name and signature of the function enclosing the cursor
+func (c *checker) enclosingFunc(cur inspector.Cursor) (string, *types.Signature) {
+	for fn := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		switch f := fn.Node().(type) {
+		case *ast.FuncDecl:
+			obj, ok := c.pass.TypesInfo.Defs[f.Name].(*types.Func)
+			if !ok {
+				return "", nil
+			}
+			return f.Name.Name, obj.Type().(*types.Signature)
+		case *ast.FuncLit:
+			sig, _ := c.pass.TypesInfo.TypeOf(f).(*types.Signature)
+			return "", sig
+		}
+	}
+
+	return "", nil
+}
+
+// isDurationToIntConversion returns true if the expression converts a duration to an integer type, e.g. `int64(d)`
+func (c *checker) isDurationToIntConversion(expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+
+	tv, ok := c.pass.TypesInfo.Types[call.Fun]
+	if !ok || !tv.IsType() || !isInteger(tv.Type) || c.classifier.IsDuration(tv.Type) {
+		return false
+	}
+
+	return c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(call.Args[0]))
+}
@@ -0,0 +1,108 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// accessorUnits maps the time.Duration methods returning a count of a unit, as a float or an integer, to the unit
+// constant
+var accessorUnits = map[string]string{
+	"Hours":        "Hour",
+	"Minutes":      "Minute",
+	"Seconds":      "Second",
+	"Milliseconds": "Millisecond",
+	"Microseconds": "Microsecond",
+	"Nanoseconds":  "Nanosecond",
+}
+
+// checkRoundTrip reports durations converted to a count of a unit by an accessor and scaled back by a unit, e.g.
+// `time.Duration(d.Seconds()) * time.Second` or `time.Duration(d.Milliseconds()) * time.Millisecond`, which truncate
+// d to whole units, or mix up the units when they differ. A round trip through nanoseconds is only redundant.
+func (c *checker) checkRoundTrip(expr *ast.BinaryExpr) {
+	if expr.Op != token.MUL {
+		return
+	}
+
+	conversion, unit := expr.X, expr.Y
+	if !isUnitConstant(c.pass, unit) {
+		conversion, unit = unit, conversion
+	}
+
+	if !isUnitConstant(c.pass, unit) {
+		return
+	}
+
+	call, ok := ast.Unparen(conversion).(*ast.CallExpr)
+	if !ok || !c.classifier.IsConversion(call) {
+		return
+	}
+
+	d, accessor, ok := c.durationAccessor(call.Args[0])
+	if !ok {
+		return
+	}
+
+	unitName := ast.Unparen(unit).(*ast.SelectorExpr).Sel.Name
+	if accessorUnits[accessor] != unitName {
+		c.reportf(RuleRoundTrip, expr, "Duration `%s` converted to %s is scaled by %s: the units don't match, use `%s`",
+			c.formatExpr(d), accessor, c.formatExpr(unit), c.formatExpr(d))
+		return
+	}
+
+	receiver := formatNode(d)
+	if _, ok := ast.Unparen(d).(*ast.BinaryExpr); ok {
+		receiver = "(" + receiver + ")"
+	}
+
+	edit := func(replacement string) []analysis.TextEdit {
+		return []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(replacement)}}
+	}
+
+	fixes := []analysis.SuggestedFix{{Message: "Use the duration", TextEdits: edit(formatNode(d))}}
+	if unitName == "Nanosecond" {
+		c.reportFixf(RuleRoundTrip, expr, fixes, "Round trip of duration `%s` through Nanoseconds is redundant: use `%s`",
+			c.formatExpr(d), c.formatExpr(d))
+		return
+	}
+
+	fixes = append(fixes, analysis.SuggestedFix{Message: "Truncate the duration", TextEdits: edit(receiver + ".Truncate(" + formatNode(unit) + ")")})
+
+	c.reportFixf(RuleRoundTrip, expr, fixes, "Round trip of duration `%s` through %s truncates it: use `%s` or `%s.Truncate(%s)`",
+		c.formatExpr(d), accessor, c.formatExpr(d), truncate(receiver, c.settings.maxExprLen), c.formatExpr(unit))
+}
+
+// durationAccessor returns the duration and the accessor of a call to one of the accessorUnits, e.g. d and Seconds
+// for `d.Seconds()`, looking through numeric conversions such as `int64(d.Seconds())`
+func (c *checker) durationAccessor(expr ast.Expr) (ast.Expr, string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil, "", false
+	}
+
+	if tv, ok := c.pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+		if _, ok := tv.Type.Underlying().(*types.Basic); ok && len(call.Args) == 1 {
+			return c.durationAccessor(call.Args[0])
+		}
+		return nil, "", false
+	}
+
+	selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, "", false
+	}
+
+	if _, ok := accessorUnits[selector.Sel.Name]; !ok || !c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(selector.X)) {
+		return nil, "", false
+	}
+
+	fn, ok := c.pass.TypesInfo.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "time" {
+		return nil, "", false
+	}
+
+	return ast.Unparen(selector.X), selector.Sel.Name, true
+}
@@ -0,0 +1,188 @@
+package durationcheck
+
+import "fmt"
+
+// Rule codes identify the individual checks in configuration files.
+const (
+	// RuleMul reports multiplications of two durations.
+	RuleMul = "mul"
+	// RuleNames reports conversions of values with non-time names to durations.
+	RuleNames = "names"
+	// RuleBitwise reports bitwise operations on durations.
+	RuleBitwise = "bitwise"
+	// RuleReturnInt reports durations converted to integers in the return statements of functions returning integers.
+	RuleReturnInt = "return-int"
+	// RuleIntParams reports integer parameters of exported functions named like durations.
+	RuleIntParams = "int-params"
+	// RuleFloatCount reports floats converted to durations before being scaled by a unit.
+	RuleFloatCount = "float-count"
+	// RuleIntDivision reports integers divided before being converted to durations and scaled by a unit.
+	RuleIntDivision = "int-division"
+	// RuleUnitChain reports values scaled by chains of three or more unit constants.
+	RuleUnitChain = "unit-chain"
+	// RuleSentinel reports arithmetic on the math.MaxInt64 duration used as an infinite timeout.
+	RuleSentinel = "sentinel"
+	// RuleUnsigned reports conversions of 64-bit unsigned integers to durations without a prior bound check.
+	RuleUnsigned = "unsigned"
+	// RuleIntAccumulator reports durations converted to integers and accumulated into integer variables.
+	RuleIntAccumulator = "int-accumulator"
+	// RuleUnscaledAdd reports bare integer constants added to or subtracted from duration variables.
+	RuleUnscaledAdd = "unscaled-add"
+	// RuleBareInit reports explicitly typed duration declarations initialized with bare numbers.
+	RuleBareInit = "bare-init"
+	// RuleFlagDefault reports duration flags defined with bare numbers as default values.
+	RuleFlagDefault = "flag-default"
+	// RuleUnitArgs reports durations converted to integers and passed to parameters expecting another unit.
+	RuleUnitArgs = "unit-args"
+	// RuleDurationpb reports unit errors around protobuf durationpb.Duration values.
+	RuleDurationpb = "durationpb"
+	// RuleWrapperInit reports duration wrapper types of third-party APIs initialized with bare numbers.
+	RuleWrapperInit = "wrapper-init"
+	// RuleCalendar reports time.Month and time.Weekday values converted to durations.
+	RuleCalendar = "calendar"
+	// RuleClockField reports clock fields of a time.Time, e.g. Nanosecond(), converted to durations.
+	RuleClockField = "clock-field"
+	// RuleTimeoutCalls reports calls to functions of other packages taking integer timeouts.
+	RuleTimeoutCalls = "timeout-calls"
+	// RuleZeroConst reports constant duration expressions evaluating to zero.
+	RuleZeroConst = "zero-const"
+	// RuleConstFraction reports divisions of untyped constants truncating inside duration arithmetic.
+	RuleConstFraction = "const-fraction"
+	// RuleDoubleCast reports products of two variables both converted to durations.
+	RuleDoubleCast = "double-cast"
+	// RuleSleepLiteral reports bare numbers of nanoseconds passed to functions waiting for or scheduling after a
+	// duration, such as time.Sleep, time.NewTicker and context.WithTimeout.
+	RuleSleepLiteral = "sleep-literal"
+	// RuleCompareLiteral reports durations compared to bare numbers.
+	RuleCompareLiteral = "compare-literal"
+	// RuleOverflow reports multiplications whose constant factors exceed the range of durations.
+	RuleOverflow = "overflow"
+	// RuleRoundTrip reports durations converted to a count of a unit by an accessor and scaled back by a unit.
+	RuleRoundTrip = "round-trip"
+	// RuleQuotient reports quotients of durations, which are counts, used as durations.
+	RuleQuotient = "quotient"
+	// RuleRedundantUnit reports durations multiplied or divided by time.Nanosecond.
+	RuleRedundantUnit = "redundant-unit"
+	// RulePrintf reports durations formatted with an integer verb.
+	RulePrintf = "printf"
+	// RuleTaggedField reports exported duration fields with json or yaml tags.
+	RuleTaggedField = "tagged-field"
+	// RuleUnitSuffix reports conversions to durations of numbers named in a unit that aren't scaled by it.
+	RuleUnitSuffix = "unit-suffix"
+	// RuleRawInt reports integer conversions of durations used as counts of seconds or milliseconds.
+	RuleRawInt = "raw-int"
+	// RuleParsedRescale reports durations parsed from strings multiplied by a unit.
+	RuleParsedRescale = "parsed-rescale"
+	// RuleMulCalls reports calls passing two durations carrying a unit to functions multiplying them together.
+	RuleMulCalls = "mul-calls"
+	// RuleEpochDiff reports differences of Unix timestamps in seconds or milliseconds converted to durations.
+	RuleEpochDiff = "epoch-diff"
+)
+
+// rule describes one of the checks
+type rule struct {
+	code string
+	// optIn rules are disabled unless their flag is set or a configuration file enables them
+	optIn bool
+	// usage is the usage of the flag enabling an opt-in rule
+	usage string
+	// anyPackage rules also apply to packages without durations
+	anyPackage bool
+	// probable rules rely on heuristics, such as the names of the values or thresholds, rather than on the units the
+	// types prove, see ConfidenceProbable
+	probable bool
+}
+
+// rules lists every known rule
+var rules = []*rule{
+	{code: RuleMul},
+	{code: RuleNames, optIn: true, probable: true, usage: "flag conversions of identifiers with non-time names (port, id, count...) used in duration arithmetic"},
+	{code: RuleBitwise, optIn: true, usage: "flag bitwise operations (&, |, ^, &^) on durations"},
+	{code: RuleReturnInt, optIn: true, probable: true, usage: "flag durations converted to integers in the return statements of functions returning integers"},
+	{code: RuleIntParams, optIn: true, probable: true, anyPackage: true, usage: "flag integer parameters of exported functions named like durations (timeout, ttl, interval...)"},
+	{code: RuleFloatCount, optIn: true, usage: "flag floats converted to durations before being scaled by a unit, which truncates their fractional part"},
+	{code: RuleIntDivision, optIn: true, usage: "flag integers divided before being scaled by a unit when a finer unit avoids the truncation, e.g. time.Duration(ms/1000) * time.Second"},
+	{code: RuleUnitChain, optIn: true, usage: "flag values scaled by chains of three or more unit constants, e.g. d * time.Second / time.Millisecond * time.Millisecond"},
+	{code: RuleSentinel, optIn: true, usage: "flag additions and multiplications involving the math.MaxInt64 duration used as an infinite timeout, which overflow"},
+	{code: RuleUnsigned, optIn: true, probable: true, usage: "flag conversions of 64-bit unsigned integers to durations without a prior bound check, which can wrap to negative durations"},
+	{code: RuleIntAccumulator, optIn: true, probable: true, usage: "flag durations converted to integers and accumulated into integer variables, e.g. total += int64(d)"},
+	{code: RuleUnscaledAdd, optIn: true, probable: true, usage: "flag bare integer constants above -unscaled-threshold added to or subtracted from duration variables, e.g. total += 100"},
+	{code: RuleBareInit, optIn: true, probable: true, usage: "flag explicitly typed duration declarations initialized with bare numbers above -unscaled-threshold, e.g. const timeout time.Duration = 30"},
+	{code: RuleFlagDefault, optIn: true, probable: true, usage: "flag duration flags of the -flag-packages defined with bare numbers above -unscaled-threshold as default values, e.g. flag.Duration(\"timeout\", 30, \"\")"},
+	{code: RuleUnitArgs, optIn: true, probable: true, usage: "flag durations converted to integers and passed to parameters expecting another unit, per -unit-apis or their names, e.g. SetTimeoutMs(int(d))"},
+	{code: RuleDurationpb, optIn: true, usage: "flag durations computed from the Seconds and Nanos fields of protobuf durationpb.Duration values instead of AsDuration, and counts passed to durationpb.New"},
+	{code: RuleWrapperInit, optIn: true, probable: true, usage: "flag duration wrapper types of the -profiles initialized with bare numbers above -unscaled-threshold, e.g. metav1.Duration{Duration: 30}"},
+	{code: RuleCalendar, optIn: true, usage: "flag time.Month and time.Weekday values converted to durations, e.g. d * time.Duration(month)"},
+	{code: RuleClockField, optIn: true, usage: "flag clock fields of a time.Time converted to durations, e.g. time.Duration(time.Now().Nanosecond())"},
+	{code: RuleTimeoutCalls, optIn: true, probable: true, anyPackage: true, usage: "flag calls to functions of other packages whose integer parameters are named like durations, as int-params reports them (needs a driver supporting facts)"},
+	{code: RuleZeroConst, optIn: true, usage: "flag constant duration expressions evaluating to zero without a zero operand, e.g. time.Second / 1024 / 1024 / 1024"},
+	{code: RuleConstFraction, optIn: true, usage: "flag divisions of untyped constants discarding a remainder inside duration arithmetic, e.g. d * (1 / 2)"},
+	{code: RuleDoubleCast, optIn: true, probable: true, usage: "flag products of two variables both converted to durations, e.g. time.Duration(timeout) * time.Duration(interval)"},
+	{code: RuleSleepLiteral, optIn: true, probable: true, usage: "flag bare numbers above -unscaled-threshold and integers converted to durations passed to time.Sleep, time.NewTicker, context.WithTimeout and other functions waiting for a duration, e.g. time.Sleep(5)"},
+	{code: RuleCompareLiteral, optIn: true, probable: true, usage: "flag durations compared to bare numbers above -unscaled-threshold, e.g. elapsed > 1000"},
+	{code: RuleOverflow, optIn: true, usage: "flag multiplications whose constant factors alone exceed the range of durations, e.g. time.Duration(n) * time.Hour * 3000000"},
+	{code: RuleRoundTrip, optIn: true, usage: "flag durations converted to a count of a unit by an accessor and scaled back by a unit, e.g. time.Duration(d.Seconds()) * time.Second or time.Duration(d.Milliseconds()) * time.Millisecond"},
+	{code: RuleQuotient, optIn: true, usage: "flag quotients of durations, which are counts, passed to duration parameters or assigned to duration fields, e.g. time.Sleep(total / interval)"},
+	{code: RuleRedundantUnit, optIn: true, usage: "flag durations multiplied or divided by time.Nanosecond as if they were raw counts, e.g. d * time.Nanosecond or int64(d / time.Nanosecond)"},
+	{code: RulePrintf, optIn: true, usage: "flag durations formatted with an integer verb by printf-like functions, e.g. fmt.Sprintf(\"%d ms\", d), which prints nanoseconds"},
+	{code: RuleTaggedField, optIn: true, probable: true, usage: "flag exported duration fields with json or yaml tags of structs without custom marshaling, which are encoded as nanoseconds and can't be decoded from \"30s\""},
+	{code: RuleUnitSuffix, optIn: true, probable: true, usage: "flag conversions to durations of numbers named in a unit that aren't scaled by it, e.g. time.Duration(timeoutSeconds) or time.Duration(cfg.IntervalMs)"},
+	{code: RuleRawInt, optIn: true, probable: true, usage: "flag integer conversions of durations compared to small constants or stored under names in a unit, e.g. int64(d) > 30 or timeoutSeconds := int(d)"},
+	{code: RuleParsedRescale, optIn: true, usage: "flag durations parsed by time.ParseDuration, bound to duration flags or returned by the -parse-funcs multiplied by a unit, e.g. d * time.Second with d, _ := time.ParseDuration(s)"},
+	{code: RuleMulCalls, optIn: true, usage: "flag calls passing two durations carrying a unit to functions multiplying these parameters together, e.g. scale(timeout, time.Second) with func scale(a, b time.Duration) time.Duration { return a * b } (needs a driver supporting facts for the functions of other packages)"},
+	{code: RuleEpochDiff, optIn: true, usage: "flag differences of Unix timestamps in seconds, milliseconds or microseconds converted to durations without a unit, e.g. time.Duration(t2.Unix() - t1.Unix()) or time.Duration(time.Now().UnixMilli() - start)"},
+}
+
+// Confidences of the findings, the confidence of a rule.
+const (
+	// ConfidenceCertain findings are proven by the types and the units of the operands, e.g. unit × unit products.
+	ConfidenceCertain = "certain"
+	// ConfidenceProbable findings rely on heuristics, e.g. the names of the values, and are more often intentional.
+	ConfidenceProbable = "probable"
+)
+
+// RuleConfidence returns the confidence of the findings of a rule, certain or probable, or "" for unknown codes.
+func RuleConfidence(code string) string {
+	r := lookupRule(code)
+	switch {
+	case r == nil:
+		return ""
+	case r.probable:
+		return ConfidenceProbable
+	default:
+		return ConfidenceCertain
+	}
+}
+
+// docsURL is the documentation of the rules, with a section named after each code
+const docsURL = "https://github.com/charithe/durationcheck/blob/master/docs/rules.md"
+
+// RuleURL returns the URL of the documentation of a rule, explaining the bugs it reports and how to fix them, or of the
+// directive and incomplete categories of diagnostics. It returns "" for unknown codes.
+func RuleURL(code string) string {
+	if lookupRule(code) == nil && code != categoryDirective && code != categoryIncomplete {
+		return ""
+	}
+
+	return docsURL + "#" + code
+}
+
+func lookupRule(code string) *rule {
+	for _, r := range rules {
+		if r.code == code {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func validateRules(codes []string) error {
+	for _, code := range codes {
+		if lookupRule(code) == nil {
+			return fmt.Errorf("unknown rule %q", code)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package durationcheck
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRuleDocs(t *testing.T) {
+	data, err := os.ReadFile("docs/rules.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections := map[string]bool{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if code, ok := strings.CutPrefix(line, "## "); ok {
+			sections[code] = true
+		}
+	}
+
+	codes := []string{categoryDirective, categoryIncomplete}
+	for _, r := range rules {
+		codes = append(codes, r.code)
+	}
+
+	for _, code := range codes {
+		if !sections[code] {
+			t.Errorf("docs/rules.md has no section for %s", code)
+		}
+
+		if url := RuleURL(code); url != docsURL+"#"+code {
+			t.Errorf("RuleURL(%q) = %q", code, url)
+		}
+	}
+
+	if url := RuleURL("unknown"); url != "" {
+		t.Errorf("RuleURL of an unknown code = %q, want none", url)
+	}
+}
+
+func TestDiagnosticURL(t *testing.T) {
+	testdata := analysistest.TestData()
+	for _, result := range analysistest.Run(t, testdata, Analyzer, "a") {
+		for _, diag := range result.Diagnostics {
+			if diag.URL != RuleURL(diag.Category) {
+				t.Errorf("diagnostic %q of category %s links to %q", diag.Message, diag.Category, diag.URL)
+			}
+		}
+	}
+}
+
+// TestRuleConfidence checks that the documentation marks the probable rules, and only them
+func TestRuleConfidence(t *testing.T) {
+	data, err := os.ReadFile("docs/rules.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marked := map[string]bool{}
+	var code string
+	for _, line := range strings.Split(string(data), "\n") {
+		if c, ok := strings.CutPrefix(line, "## "); ok {
+			code = c
+		} else if line == "*Confidence: probable.*" {
+			marked[code] = true
+		}
+	}
+
+	for _, r := range rules {
+		if got := RuleConfidence(r.code) == ConfidenceProbable; got != marked[r.code] {
+			t.Errorf("rule %s: probable = %t, documented as probable = %t", r.code, got, marked[r.code])
+		}
+	}
+
+	if got := RuleConfidence(RuleMul); got != ConfidenceCertain {
+		t.Errorf("RuleConfidence(%q) = %q, want %q", RuleMul, got, ConfidenceCertain)
+	}
+
+	if got := RuleConfidence("unknown"); got != "" {
+		t.Errorf("RuleConfidence of an unknown code = %q, want none", got)
+	}
+}
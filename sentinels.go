@@ -0,0 +1,51 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"math"
+)
+
+// checkSentinelArithmetic reports additions and multiplications involving the math.MaxInt64 duration used as an
+// "infinite" timeout, e.g. `forever + jitter`, which overflow into negative durations
+func (c *checker) checkSentinelArithmetic(expr *ast.BinaryExpr) {
+	if expr.Op != token.ADD && expr.Op != token.MUL {
+		return
+	}
+
+	// constant overflows are compilation errors
+	if c.isConstant(expr) {
+		return
+	}
+
+	for _, operand := range []ast.Expr{expr.X, expr.Y} {
+		if c.isSentinel(operand) {
+			c.reportf(RuleSentinel, expr, "Arithmetic on the math.MaxInt64 sentinel duration `%s` overflows: `%s`", c.formatExpr(operand), c.formatExpr(expr))
+			return
+		}
+	}
+}
+
+// checkSentinelAssignment reports the compound assignments adding or multiplying the math.MaxInt64 duration
+func (c *checker) checkSentinelAssignment(stmt *ast.AssignStmt) {
+	if stmt.Tok != token.ADD_ASSIGN && stmt.Tok != token.MUL_ASSIGN || len(stmt.Rhs) != 1 {
+		return
+	}
+
+	if c.isSentinel(stmt.Rhs[0]) {
+		c.reportf(RuleSentinel, stmt, "Arithmetic on the math.MaxInt64 sentinel duration `%s` overflows: `%s`", c.formatExpr(stmt.Rhs[0]), c.formatExpr(stmt))
+	}
+}
+
+// isSentinel reports whether the expression is a constant duration equal to math.MaxInt64, e.g.
+// `time.Duration(math.MaxInt64)` or a constant declared with it
+func (c *checker) isSentinel(expr ast.Expr) bool {
+	tv, ok := c.pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || !c.classifier.IsDuration(tv.Type) {
+		return false
+	}
+
+	value, exact := constant.Int64Val(tv.Value)
+	return exact && value == math.MaxInt64
+}
@@ -0,0 +1,96 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+)
+
+// durationConsumers maps the functions and methods waiting for or scheduling after a duration to the index of their
+// duration argument
+var durationConsumers = map[string]int{
+	"time.Sleep":               0, // Sleep(d)
+	"time.After":               0, // After(d)
+	"time.AfterFunc":           0, // AfterFunc(d, f)
+	"time.Tick":                0, // Tick(d)
+	"time.NewTicker":           0, // NewTicker(d)
+	"time.NewTimer":            0, // NewTimer(d)
+	"time.Ticker.Reset":        0, // (*Ticker).Reset(d)
+	"time.Timer.Reset":         0, // (*Timer).Reset(d)
+	"time.Time.Add":            0, // Time.Add(d), e.g. conn.SetDeadline(time.Now().Add(d))
+	"context.WithTimeout":      1, // WithTimeout(parent, timeout)
+	"context.WithTimeoutCause": 1, // WithTimeoutCause(parent, timeout, cause)
+}
+
+// checkSleepArgument reports calls waiting for a bare number of nanoseconds, e.g. `time.Sleep(5)`,
+// `context.WithTimeout(ctx, time.Duration(ms))` or `time.NewTicker(time.Duration(n) * 2)`, and suggests scaling the
+// argument by the common units
+func (c *checker) checkSleepArgument(call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return
+	}
+
+	name := funcKey(fn)
+	i, ok := durationConsumers[name]
+	if !ok || i >= len(call.Args) {
+		return
+	}
+
+	arg := call.Args[i]
+	if tv, ok := c.pass.TypesInfo.Types[arg]; !ok || tv.Type == types.Typ[types.Invalid] {
+		// an undefined argument in a package with type errors, e.g. a buffer being typed
+		return
+	}
+
+	if c.classifier.Classify(arg) != durationexpr.Count {
+		return
+	}
+
+	if value := c.pass.TypesInfo.Types[arg].Value; value != nil {
+		n, exact := constant.Int64Val(constant.ToInt(value))
+		if !exact || n <= int64(c.settings.unscaledThreshold) && n >= -int64(c.settings.unscaledThreshold) {
+			return
+		}
+
+		c.reportFixf(RuleSleepLiteral, arg, c.scaleFixes(arg), "Argument `%s` of %s is %d nanoseconds: multiply it by a unit",
+			c.formatExpr(arg), name, n)
+		return
+	}
+
+	// times are commonly offset by computed nanosecond deltas, e.g. `time.Now().Add(time.Duration(delta))`, only
+	// their bare constants are reported
+	if name == "time.Time.Add" {
+		return
+	}
+
+	// a variable count is built from conversions of plain numbers, e.g. `time.Duration(n) * 2`
+	c.reportFixf(RuleSleepLiteral, arg, c.scaleFixes(arg), "Argument `%s` of %s is a count of nanoseconds: multiply it by a unit",
+		c.formatExpr(arg), name)
+}
+
+// scaleFixes returns the rewrites scaling a bare number by the common units
+func (c *checker) scaleFixes(expr ast.Expr) []analysis.SuggestedFix {
+	qualifier, ok := c.timeQualifier()
+	if !ok {
+		return nil
+	}
+
+	operand := formatNode(expr)
+	if _, ok := ast.Unparen(expr).(*ast.BinaryExpr); ok {
+		operand = "(" + operand + ")"
+	}
+
+	fixes := make([]analysis.SuggestedFix, 0, len(fixUnits))
+	for _, unit := range fixUnits {
+		fixes = append(fixes, analysis.SuggestedFix{
+			Message:   "Scale by " + qualifier + "." + unit,
+			TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(operand + " * " + qualifier + "." + unit)}},
+		})
+	}
+
+	return fixes
+}
@@ -0,0 +1,113 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/charithe/durationcheck/durationexpr"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkUnitSuffixedConversion reports conversions to durations of numbers named in a unit, e.g.
+// `time.Duration(timeoutSeconds)` or `time.Duration(cfg.IntervalMs)`, that the product they are part of doesn't scale
+// by that unit. Products scaled by another unit are reported as well, while those involving a duration variable, whose
+// unit is unknown, and divisions by the conversion are not.
+func (c *checker) checkUnitSuffixedConversion(cur inspector.Cursor, call *ast.CallExpr) {
+	if !c.classifier.IsConversion(call) {
+		return
+	}
+
+	arg := ast.Unparen(call.Args[0])
+	name := identName(arg)
+	unit := nameUnit(name)
+	if unit == "" || unit == "Nanosecond" {
+		return
+	}
+
+	tv, ok := c.pass.TypesInfo.Types[arg]
+	if !ok || tv.Value != nil || c.classifier.IsDuration(tv.Type) {
+		return
+	}
+
+	if basic, ok := tv.Type.Underlying().(*types.Basic); !ok || basic.Info()&(types.IsInteger|types.IsFloat) == 0 {
+		return
+	}
+
+	// dividing by a count is fine whatever its unit, e.g. `interval / time.Duration(waitMinutes)`
+	if quo, ok := cur.Parent().Node().(*ast.BinaryExpr); ok && quo.Op == token.QUO && quo.Y == ast.Expr(call) {
+		return
+	}
+
+	var scaled []string
+	for _, operand := range multiplicationOperands(enclosingProduct(cur)) {
+		switch {
+		case operand == ast.Expr(call):
+		case isUnitConstant(c.pass, operand):
+			scaled = append(scaled, ast.Unparen(operand).(*ast.SelectorExpr).Sel.Name)
+		case c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(operand)) && c.classifier.Classify(operand) == durationexpr.Unit:
+			return
+		}
+	}
+
+	expects := strings.ToLower(unit) + "s"
+	qualifier, ok := c.timeQualifier()
+	if !ok {
+		qualifier = "time"
+	}
+
+	if len(scaled) == 0 {
+		c.reportFixf(RuleUnitSuffix, call, c.unitSuffixFixes(call, cur.Parent().Node(), unit),
+			"Conversion of `%s`, named in %s, to duration isn't scaled: multiply it by %s.%s", name, expects, qualifier, unit)
+		return
+	}
+
+	for _, s := range scaled {
+		if s == unit {
+			return
+		}
+	}
+
+	c.reportf(RuleUnitSuffix, call, "Conversion of `%s`, named in %s, to duration is scaled by %s.%s: multiply it by %s.%s instead",
+		name, expects, qualifier, scaled[0], qualifier, unit)
+}
+
+// unitSuffixFixes returns the rewrite scaling the conversion by the unit, parenthesized where the parent node binds
+// tighter than the multiplication
+func (c *checker) unitSuffixFixes(call *ast.CallExpr, parent ast.Node, unit string) []analysis.SuggestedFix {
+	qualifier, ok := c.timeQualifier()
+	if !ok {
+		return nil
+	}
+
+	replacement := formatNode(call) + " * " + qualifier + "." + unit
+	switch p := parent.(type) {
+	case *ast.UnaryExpr, *ast.SelectorExpr:
+		replacement = "(" + replacement + ")"
+	case *ast.BinaryExpr:
+		if p.Op != token.MUL && p.Op.Precedence() == token.MUL.Precedence() {
+			replacement = "(" + replacement + ")"
+		}
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Scale by " + qualifier + "." + unit,
+		TextEdits: []analysis.TextEdit{{Pos: call.Pos(), End: call.End(), NewText: []byte(replacement)}},
+	}}
+}
+
+// enclosingProduct returns the outermost chain of multiplications the expression of the cursor is an operand of,
+// parenthesized or not, or the expression itself
+func enclosingProduct(cur inspector.Cursor) ast.Expr {
+	product := cur.Node().(ast.Expr)
+	for parent := cur.Parent(); ; parent = parent.Parent() {
+		if binary, ok := parent.Node().(*ast.BinaryExpr); ok && binary.Op == token.MUL || isParen(parent.Node()) {
+			product = parent.Node().(ast.Expr)
+			continue
+		}
+
+		return product
+	}
+}
@@ -0,0 +1,84 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// encodingTags are the struct tag keys of the encodings that marshal durations as integers, and the methods of the
+// types customizing them
+var encodingTags = []struct {
+	key     string
+	methods []string
+}{
+	{key: "json", methods: []string{"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText"}},
+	{key: "yaml", methods: []string{"MarshalYAML", "UnmarshalYAML", "MarshalText", "UnmarshalText"}},
+}
+
+// checkTaggedFields reports the exported duration fields with json or yaml tags of struct type declarations, e.g. a
+// `Timeout time.Duration` field tagged `json:"timeout"`, which encoding/json marshals as a count of nanoseconds and
+// fails to unmarshal from "30s". Structs and duration types with their own marshaling methods for the encoding aren't
+// reported.
+func (c *checker) checkTaggedFields(spec *ast.TypeSpec) {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+
+	obj := c.pass.TypesInfo.Defs[spec.Name]
+	if obj == nil {
+		return
+	}
+
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+
+		t := c.pass.TypesInfo.TypeOf(field.Type)
+		if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+
+		if !c.classifier.IsDuration(t) {
+			continue
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		for _, encoding := range encodingTags {
+			name, ok := reflect.StructTag(tag).Lookup(encoding.key)
+			if !ok || strings.Split(name, ",")[0] == "-" || hasMethod(obj.Type(), encoding.methods) || hasMethod(t, encoding.methods) {
+				continue
+			}
+
+			for _, ident := range field.Names {
+				if !ident.IsExported() {
+					continue
+				}
+
+				c.reportf(RuleTaggedField, ident, "Duration field `%s` has a %s tag: it is encoded as a count of nanoseconds and can't be decoded from strings like \"30s\", use a duration type encoded as a string",
+					ident.Name, encoding.key)
+			}
+			break
+		}
+	}
+}
+
+// hasMethod returns true if the type or a pointer to it has one of the methods
+func hasMethod(t types.Type, names []string) bool {
+	mset := types.NewMethodSet(types.NewPointer(t))
+	for _, name := range names {
+		if mset.Lookup(nil, name) != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,24 @@
+package acceptnames
+
+import "time"
+
+type policy struct {
+	Multiplier time.Duration
+	Timeout    time.Duration
+}
+
+func cases(backoff, retryCount, maxRetries, factor, timeout time.Duration, p policy) {
+	_ = backoff * retryCount
+
+	_ = maxRetries * time.Second
+
+	_ = time.Second * (factor)
+
+	_ = backoff * p.Multiplier
+
+	_ = backoff * timeout // want `Multiplication of durations: .backoff \* timeout.`
+
+	_ = p.Timeout * time.Second // want `Multiplication of durations: .p.Timeout \* time.Second.`
+
+	_ = backoff * time.Duration(retryCount) * time.Second // want `Multiplication of durations`
+}
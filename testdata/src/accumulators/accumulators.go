@@ -0,0 +1,37 @@
+package accumulators
+
+import "time"
+
+type stats struct {
+	elapsed int64
+}
+
+func cases(durations []time.Duration, s *stats) {
+	var total int64
+	for _, d := range durations {
+		total += int64(d) // want "Duration accumulated into integer `total` loses its unit: declare `total` as time.Duration"
+	}
+
+	var spent int
+	for _, d := range durations {
+		spent = spent + int(d) // want "Duration accumulated into integer `spent` loses its unit"
+	}
+
+	s.elapsed -= int64(durations[0]) // want "Duration accumulated into integer `s.elapsed` loses its unit"
+
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+
+	var ms int64
+	for _, d := range durations {
+		ms += d.Milliseconds()
+	}
+
+	var count int
+	count += len(durations)
+
+	_, _, _, _ = total, spent, sum, ms
+	_ = count
+}
@@ -0,0 +1,39 @@
+package aliases
+
+import (
+	"time"
+
+	"example.com/clockalias"
+)
+
+type Timeout = time.Duration
+
+// Backoff is an alias of an alias
+type Backoff = Timeout
+
+type config struct {
+	timeout  Timeout
+	interval clockalias.Interval
+}
+
+func validCases(n int, cfg config) {
+	_ = Timeout(n) * time.Second
+
+	_ = time.Second * Timeout(10)
+
+	_ = clockalias.Interval(n) * time.Millisecond
+
+	_ = Backoff(n+1) * time.Second
+
+	_ = cfg.timeout * 2
+}
+
+func invalidCases(d Timeout, cfg config) {
+	_ = d * time.Second // want `Multiplication of durations: .d \* time.Second.`
+
+	_ = Timeout(d) * time.Second // want `Multiplication of durations: .Timeout\(d\) \* time.Second.`
+
+	_ = cfg.interval * time.Second // want `Multiplication of durations: .cfg.interval \* time.Second.`
+
+	_ = clockalias.Interval(cfg.timeout) * d // want `Multiplication of durations: .clockalias.Interval\(cfg.timeout\) \* d.`
+}
@@ -0,0 +1,19 @@
+package api
+
+import "time"
+
+func sleeps(n int, d time.Duration) {
+	time.Sleep(10)               // want `count slept: 10`
+	time.Sleep(time.Duration(n)) // want `count slept: time.Duration\(n\)`
+	time.Sleep(d)
+	time.Sleep(2 * time.Second)                     // want `operands: count, unit`
+	time.Sleep(time.Duration(n) * time.Millisecond) // want `operands: count, unit`
+}
+
+func products(n int, d time.Duration) {
+	_ = d * time.Second                 // want `suspicious: unit, unit`
+	_ = time.Duration(n) * time.Second  // want `operands: count, unit`
+	_ = 2 * d * time.Second             // want `suspicious: count, unit, unit`
+	_ = (d / time.Second) * time.Second // want `operands: unit, unit`
+	_ = n * 2
+}
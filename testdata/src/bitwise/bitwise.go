@@ -0,0 +1,19 @@
+package bitwise
+
+import "time"
+
+const mask time.Duration = 0xff
+
+func cases(d time.Duration, n int64) {
+	_ = d & mask // want `Bitwise operation on durations`
+
+	_ = d | time.Second // want `Bitwise operation on durations`
+
+	_ = d ^ 1 // want `Bitwise operation on durations`
+
+	_ = d &^ mask // want `Bitwise operation on durations`
+
+	_ = n & 0xff
+
+	_ = d * 2
+}
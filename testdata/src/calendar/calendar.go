@@ -0,0 +1,13 @@
+package calendar
+
+import "time"
+
+func cases(t time.Time, d time.Duration, names []string) {
+	_ = d * time.Duration(t.Month()) // want "Conversion of time.Month `t.Month\\(\\)` to duration: calendar units aren't fixed durations, use time.Time.AddDate"
+
+	_ = time.Duration(t.Weekday()) * time.Hour // want "Conversion of time.Weekday `t.Weekday\\(\\)` to duration"
+
+	_ = time.Duration(len(names)) * time.Hour
+
+	_ = int(t.Month()) * 2
+}
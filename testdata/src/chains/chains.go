@@ -0,0 +1,22 @@
+package chains
+
+import "time"
+
+func cases(n int64, d time.Duration) {
+	_ = time.Duration(n) * time.Second / time.Millisecond * time.Millisecond // want "Convoluted unit conversion chain `time.Duration\\(n\\) \\* time.Second / time.Millisecond \\* time.Millisecond`: simplify to `time.Duration\\(n\\) \\* time.Second`"
+
+	_ = time.Second * time.Duration(n) / time.Millisecond * time.Microsecond // want "simplify to `time.Duration\\(n\\) \\* time.Millisecond`" "Multiplication of durations"
+
+	_ = time.Duration(n) * time.Minute / time.Second * time.Millisecond // want "simplify to `time.Duration\\(n\\) \\* 60 \\* time.Millisecond`" "Multiplication of durations"
+
+	_ = time.Duration(n) * time.Millisecond / time.Millisecond / time.Nanosecond // want "simplify to `time.Duration\\(n\\)`"
+
+	_ = (time.Duration(n) + 1) * 2 * time.Hour / time.Minute * time.Second // want "simplify to `\\(time.Duration\\(n\\) \\+ 1\\) \\* 2 \\* time.Minute`" "Multiplication of durations: `\\(time.Duration\\(n\\) \\+ 1\\) \\* 2 \\* time.Hour /"
+
+	// truncates d to milliseconds before the division
+	_ = d / time.Millisecond * time.Millisecond / time.Nanosecond
+
+	_ = time.Duration(n) * time.Second / time.Millisecond
+
+	_ = time.Duration(n) * time.Second * 2
+}
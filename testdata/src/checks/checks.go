@@ -0,0 +1,9 @@
+package checks
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second
+
+	_ = d & time.Second // want `Bitwise operation on durations`
+}
@@ -0,0 +1,15 @@
+package clock
+
+import "time"
+
+func cases(t *time.Time, elapsed time.Duration) {
+	_ = time.Duration(time.Now().Nanosecond()) // want "Conversion of clock field `time.Now\\(\\).Nanosecond\\(\\)` to duration only covers a second: use time.Since, UnixNano or math/rand for jitter"
+
+	time.Sleep(time.Duration(t.Second()) * time.Millisecond) // want "Conversion of clock field `t.Second\\(\\)` to duration only covers a minute"
+
+	_ = time.Duration(time.Now().UnixNano())
+
+	_ = time.Duration(elapsed.Nanoseconds())
+
+	_ = t.Nanosecond() % 100
+}
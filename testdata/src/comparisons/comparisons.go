@@ -0,0 +1,31 @@
+package comparisons
+
+import "time"
+
+type stats struct {
+	latency time.Duration
+}
+
+func cases(start time.Time, s stats, d time.Duration, n int) {
+	elapsed := time.Since(start)
+
+	if elapsed > 1000 { // want "Duration `elapsed` compared to bare number `1000`, which is 1000 nanoseconds: multiply it by a unit"
+	}
+
+	if 30 <= s.latency { // want "Duration `s.latency` compared to bare number `30`"
+	}
+
+	_ = time.Since(start) == 500 // want "Duration `time.Since\\(start\\)` compared to bare number `500`"
+
+	_ = d != 2.5e3 // want "Duration `d` compared to bare number `2.5e3`, which is 2500 nanoseconds"
+
+	_ = elapsed > 0
+
+	_ = elapsed >= -1
+
+	_ = elapsed < 5*time.Second
+
+	_ = n > 1000
+
+	_ = time.Second > 1000
+}
@@ -0,0 +1,30 @@
+package compound
+
+import "time"
+
+type retry struct {
+	delay time.Duration
+}
+
+func validCases(n int, r *retry) {
+	timeout := time.Second
+	timeout *= 2
+
+	timeout *= time.Duration(n)
+
+	r.delay *= time.Duration(n + 1)
+
+	count := 3
+	count *= n
+}
+
+func invalidCases(backoff time.Duration, r *retry) {
+	timeout := 10 * time.Second
+	timeout *= backoff // want `Multiplication of durations in compound assignment: .timeout \*= backoff.`
+
+	timeout *= time.Second // want `Multiplication of durations in compound assignment: .timeout \*= time.Second.`
+
+	r.delay *= timeout // want `Multiplication of durations in compound assignment: .r.delay \*= timeout.`
+
+	timeout *= time.Duration(backoff) // want `Multiplication of durations in compound assignment: .timeout \*= time.Duration\(backoff\).`
+}
@@ -0,0 +1,18 @@
+package confidence
+
+import "time"
+
+func cases(timeout time.Duration, port, timeoutSeconds int) {
+	_ = timeout * time.Second // want `Multiplication of durations`
+
+	_ = timeout & time.Second // want `Bitwise operation`
+
+	// names and unit-suffix are heuristic rules, skipped with -min-confidence=certain
+	_ = time.Duration(port) * time.Second
+
+	_ = time.Duration(timeoutSeconds)
+
+	// bare-init and sleep-literal rely on -unscaled-threshold, their findings are only probable too
+	const defaultTimeout time.Duration = 30
+	time.Sleep(5)
+}
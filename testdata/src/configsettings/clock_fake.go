@@ -0,0 +1,7 @@
+package configsettings
+
+import "time"
+
+func fake(d time.Duration) {
+	_ = d * time.Second
+}
@@ -0,0 +1,15 @@
+package configsettings
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func cases(interval model.Duration, maxRetries, backoff time.Duration) {
+	_ = interval * model.Duration(time.Second) // want `Multiplication of durations`
+
+	_ = backoff * maxRetries
+
+	_ = backoff * time.Second // want `Multiplication of durations`
+}
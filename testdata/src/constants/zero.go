@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+const (
+	pollInterval = time.Second / 1024 / 1024 / 1024 // want "Constant duration `time.Second / 1024 / 1024 / 1024` evaluates to zero"
+
+	disabled = 0 * time.Second
+
+	none time.Duration = 0
+)
+
+func zero(d time.Duration) {
+	time.Sleep(time.Duration(1/2) * time.Second) // want "Constant duration `time.Duration\\(1/2\\) \\* time.Second` evaluates to zero"
+
+	_ = (time.Millisecond / 2000) + time.Second
+
+	_ = time.Millisecond - time.Millisecond // want "evaluates to zero"
+
+	_ = none * 2
+
+	_ = d / 1024 / 1024
+
+	_ = time.Second / 1024
+}
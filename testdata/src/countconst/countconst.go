@@ -0,0 +1,26 @@
+package countconst
+
+import "time"
+
+const (
+	retries    time.Duration = 3
+	multiplier time.Duration = -10
+	timeout    time.Duration = 30 * time.Second
+	large      time.Duration = 1000
+)
+
+func cases(d time.Duration) {
+	_ = retries * time.Second
+
+	_ = time.Millisecond * multiplier
+
+	_ = time.Duration(retries) * time.Second
+
+	_ = d * time.Nanosecond // want `Multiplication of durations: .d \* time.Nanosecond.`
+
+	_ = d * timeout // want `Multiplication of durations: .d \* timeout.`
+
+	_ = large * time.Second // want `Multiplication of durations: .large \* time.Second.`
+
+	_ = retries * d
+}
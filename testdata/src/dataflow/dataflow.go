@@ -0,0 +1,47 @@
+package dataflow
+
+import "time"
+
+type config struct {
+	TimeoutSeconds int
+	Timeout        time.Duration
+}
+
+var interval = time.Duration(5)
+
+func counts(cfg config, n int, retry bool) {
+	d := time.Duration(cfg.TimeoutSeconds)
+	_ = d * time.Second
+
+	attempts := time.Duration(n)
+	if retry {
+		attempts = time.Duration(n * 2)
+	}
+	_ = time.Millisecond * attempts
+
+	_ = -d * time.Second
+
+	_ = time.Duration(n) * time.Second
+}
+
+func units(cfg config, start time.Time, n int, retry bool) {
+	elapsed := time.Since(start)
+	_ = elapsed * time.Millisecond // want `Multiplication of durations: .elapsed \* time.Millisecond.`
+
+	ms := int64(elapsed)
+	_ = time.Duration(ms) * time.Second // want `Multiplication of durations: .time.Duration\(ms\) \* time.Second.`
+
+	d := time.Duration(n)
+	if retry {
+		d = cfg.Timeout
+	}
+	_ = d * time.Second // want `Multiplication of durations: .d \* time.Second.`
+
+	_ = cfg.Timeout * time.Second // want `Multiplication of durations: .cfg.Timeout \* time.Second.`
+
+	_ = interval * time.Second // want `Multiplication of durations: .interval \* time.Second.`
+
+	_ = func() time.Duration {
+		return elapsed * time.Second // want `Multiplication of durations: .elapsed \* time.Second.`
+	}
+}
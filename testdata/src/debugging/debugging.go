@@ -0,0 +1,13 @@
+package debugging
+
+import "time"
+
+func multiplications(n int, timeout, retries time.Duration) {
+	_ = timeout * time.Second // want "Multiplication of durations"
+
+	_ = time.Duration(n) * time.Second
+
+	_ = retries * time.Second
+
+	_ = 2 * n
+}
@@ -0,0 +1,21 @@
+package declarations
+
+import "time"
+
+const defaultTimeout time.Duration = 30 // want "Duration `defaultTimeout` initialized with bare number `30` is 30 nanoseconds: multiply it by a unit"
+
+var ttl time.Duration = 500 // want "Duration `ttl` initialized with bare number `500` is 500 nanoseconds"
+
+const (
+	retry    time.Duration = 1
+	interval time.Duration = 5 * time.Second
+	inferred               = 30
+)
+
+func cases() {
+	var backoff time.Duration = 250 // want "Duration `backoff` initialized with bare number `250`"
+
+	var d = time.Duration(100)
+
+	_, _ = backoff, d
+}
@@ -0,0 +1,23 @@
+package divisions
+
+import "time"
+
+func cases(ms, us int64, n int, d time.Duration) {
+	_ = time.Duration(ms/1000) * time.Second // want "Integer division `ms / 1000` truncates before scaling by time.Second: use `time.Duration\\(ms\\) \\* time.Millisecond`"
+
+	_ = time.Minute * time.Duration(n/60) // want "use `time.Duration\\(n\\) \\* time.Second`"
+
+	_ = time.Duration(us/1e6) * time.Second // want "use `time.Duration\\(us\\) \\* time.Microsecond`"
+
+	_ = time.Duration(n/7) * time.Second
+
+	_ = time.Duration(n/1000) * time.Millisecond // want "use `time.Duration\\(n\\) \\* time.Microsecond`"
+
+	_ = time.Duration(ms/1000) * 2
+
+	_ = time.Duration(ms) * time.Millisecond
+
+	_ = time.Duration(ms/1000)*time.Second + time.Duration(ms%1000)*time.Millisecond
+
+	_ = time.Duration(ms/1000)*time.Second + time.Duration(us%1000)*time.Millisecond // want "Integer division"
+}
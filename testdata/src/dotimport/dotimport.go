@@ -0,0 +1,23 @@
+package dotimport
+
+import (
+	. "time"
+)
+
+func validCases(n int) {
+	_ = Duration(5) * Second
+
+	_ = Millisecond * Duration(n)
+
+	_ = Duration(n*2) * Minute
+}
+
+func invalidCases(d Duration) {
+	_ = d * Second // want `Multiplication of durations: .d \* Second.`
+
+	_ = Duration(d) * Second // want `Multiplication of durations: .Duration\(d\) \* Second.`
+
+	_ = Duration(n()) * Second // want `Multiplication of durations: .Duration\(n\(\)\) \* Second.`
+}
+
+func n() Duration { return Second }
@@ -0,0 +1,24 @@
+package doublecast
+
+import "time"
+
+const retries = 3
+
+type config struct {
+	timeout  int
+	interval int
+}
+
+func cases(cfg config, timeout, interval int64, ms int) {
+	_ = time.Duration(timeout) * time.Duration(interval) // want "Product of the durations converted from variables `timeout` and `interval`: at most one of them should be a count"
+
+	_ = time.Duration(cfg.timeout) * time.Duration(cfg.interval) // want "Product of the durations converted from variables `cfg.timeout` and `cfg.interval`"
+
+	_ = time.Duration(timeout) * time.Duration(retries)
+
+	_ = time.Duration(timeout) * time.Duration(2)
+
+	_ = time.Duration(ms) * time.Millisecond
+
+	_ = time.Duration(timeout*2) * time.Duration(interval)
+}
@@ -0,0 +1,23 @@
+package durationtypes
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+type config struct {
+	ScrapeInterval model.Duration
+}
+
+func cases(cfg config, n int, d model.Duration) {
+	_ = cfg.ScrapeInterval * model.Duration(time.Second) // want `Multiplication of durations: .cfg.ScrapeInterval \* model.Duration\(time.Second\).`
+
+	_ = d * cfg.ScrapeInterval // want `Multiplication of durations: .d \* cfg.ScrapeInterval.`
+
+	_ = model.Duration(n) * cfg.ScrapeInterval
+
+	_ = cfg.ScrapeInterval * 2
+
+	_ = time.Duration(cfg.ScrapeInterval) * time.Second // want `Multiplication of durations: .time.Duration\(cfg.ScrapeInterval\) \* time.Second.`
+}
@@ -0,0 +1,33 @@
+package elapsedfix
+
+import "time"
+
+type metrics struct {
+	latencyMs time.Duration
+}
+
+func cases(start, deadline time.Time) {
+	_ = time.Since(start) * time.Millisecond // want "Multiplication of durations: `time.Since\\(start\\) \\* time.Millisecond`"
+
+	_ = time.Second * deadline.Sub(start) // want "Multiplication of durations"
+
+	elapsed := time.Since(start)
+	_ = elapsed * time.Second // want "Multiplication of durations"
+
+	elapsedMs := time.Since(start) * time.Millisecond // want "Multiplication of durations"
+	_ = elapsedMs
+
+	var remainingSeconds = time.Until(deadline) * time.Second // want "Multiplication of durations"
+	_ = remainingSeconds
+
+	_ = metrics{latencyMs: elapsed * time.Millisecond} // want "Multiplication of durations"
+
+	// the name doesn't match the unit
+	elapsedSec := time.Since(start) * time.Millisecond // want "Multiplication of durations"
+	_ = elapsedSec
+
+	// reassigned, so not provably an elapsed time
+	waited := time.Since(start)
+	waited = waited / 2
+	_ = waited * time.Second // want "Multiplication of durations"
+}
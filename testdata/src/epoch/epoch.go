@@ -0,0 +1,23 @@
+package epoch
+
+import "time"
+
+func cases(t1, t2 time.Time, start, startNano int64, created *time.Time) {
+	_ = time.Duration(t2.Unix() - t1.Unix()) // want "Difference of Unix timestamps `t2.Unix\\(\\) - t1.Unix\\(\\)` in seconds converted to duration without a unit: use `t2.Sub\\(t1\\)` or multiply it by time.Second"
+
+	_ = time.Duration(time.Now().UnixMilli() - start) // want "Difference of Unix timestamps `time.Now\\(\\).UnixMilli\\(\\) - start` in milliseconds converted to duration without a unit: multiply it by time.Millisecond"
+
+	_ = time.Duration(start-created.UnixMicro()) < time.Second // want "Difference of Unix timestamps `start - created.UnixMicro\\(\\)` in microseconds"
+
+	_ = -time.Duration(t2.UnixMilli() - t1.UnixMilli()) // want "Difference of Unix timestamps `t2.UnixMilli\\(\\) - t1.UnixMilli\\(\\)` in milliseconds"
+
+	_ = time.Duration(t2.Unix()-t1.Unix()) * time.Second
+
+	_ = 2 * time.Duration(time.Now().UnixMilli()-start) * time.Millisecond
+
+	_ = time.Duration(time.Now().UnixNano() - startNano)
+
+	_ = time.Duration(t2.Unix() - t1.UnixMilli())
+
+	_ = time.Duration(t2.Unix())
+}
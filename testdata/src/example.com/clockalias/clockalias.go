@@ -0,0 +1,6 @@
+package clockalias
+
+import "time"
+
+// Interval is an alias exported by a wrapper package
+type Interval = time.Duration
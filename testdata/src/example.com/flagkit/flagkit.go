@@ -0,0 +1,5 @@
+package flagkit
+
+import "time"
+
+func Duration(name string, value time.Duration, usage string) *time.Duration { return nil }
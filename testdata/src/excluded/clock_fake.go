@@ -0,0 +1,7 @@
+package excluded
+
+import "time"
+
+func fakeDelay(d time.Duration) time.Duration {
+	return d * time.Second
+}
@@ -0,0 +1,7 @@
+package excluded
+
+import "time"
+
+func delay(d time.Duration) time.Duration {
+	return d * time.Second // want `Multiplication of durations`
+}
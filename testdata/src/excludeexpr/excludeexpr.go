@@ -0,0 +1,20 @@
+package excludeexpr
+
+import "time"
+
+type Retrier struct {
+	jitter, spread time.Duration
+}
+
+func (r *Retrier) Backoff(d time.Duration) time.Duration {
+	jitter, spread := r.jitter, r.spread
+	return jitter*spread + d*d
+}
+
+func Poll(jitter, spread time.Duration) time.Duration {
+	return jitter * spread // want "Multiplication of durations: `jitter \\* spread`"
+}
+
+func Wait(timeout, interval time.Duration) time.Duration {
+	return timeout * interval
+}
@@ -0,0 +1,9 @@
+package clock
+
+import "time"
+
+func cases(d time.Duration, n int64) {
+	_ = d * time.Second
+
+	_ = d & 0xff // want `Bitwise operation on durations`
+}
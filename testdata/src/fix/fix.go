@@ -0,0 +1,29 @@
+package fix
+
+import (
+	"time"
+)
+
+func cases(start, deadline time.Time, elapsed, step time.Duration) {
+	_ = time.Now().Sub(start) // want "use time.Since"
+
+	_ = deadline.Sub(time.Now()) // want "use time.Until"
+
+	_ = (elapsed / time.Second) * time.Second // want "use Duration.Truncate"
+
+	_ = time.Minute * (elapsed / time.Minute) // want "use Duration.Truncate"
+
+	_ = (elapsed / step) * step // want "use Duration.Truncate"
+
+	_ = 1000 * time.Millisecond // want "use time.Second"
+
+	_ = time.Second * 60 // want "use time.Minute"
+
+	_ = 1 * time.Hour // want "use time.Hour"
+
+	_ = 2 * time.Hour
+
+	_ = (elapsed / time.Second) * time.Millisecond
+
+	_ = deadline.Sub(start)
+}
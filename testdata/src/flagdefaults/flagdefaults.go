@@ -0,0 +1,25 @@
+package flagdefaults
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func cases(fs *flag.FlagSet, pfs *pflag.FlagSet) {
+	_ = flag.Duration("timeout", 30, "request timeout") // want "Default value `30` of duration flag is 30 nanoseconds: multiply it by a unit"
+
+	var d time.Duration
+	flag.DurationVar(&d, "interval", 500, "poll interval") // want "Default value `500` of duration flag"
+
+	_ = fs.Duration("ttl", 60, "cache ttl") // want "Default value `60` of duration flag"
+
+	_ = pfs.DurationP("wait", "w", 5, "wait time") // want "Default value `5` of duration flag"
+
+	pflag.DurationVarP(&d, "delay", "d", 100, "delay") // want "Default value `100` of duration flag"
+
+	_ = flag.Duration("retry", 0, "retry delay")
+
+	_ = flag.Duration("backoff", 2*time.Second, "backoff")
+}
@@ -0,0 +1,19 @@
+package flagkit
+
+import (
+	"flag"
+	"time"
+
+	"example.com/flagkit"
+	"github.com/spf13/pflag"
+)
+
+func cases(pfs *pflag.FlagSet) {
+	_ = flagkit.Duration("timeout", 30, "request timeout") // want "Default value `30` of duration flag"
+
+	_ = flag.Duration("interval", 500, "poll interval") // want "Default value `500` of duration flag"
+
+	_ = pfs.Duration("ttl", 60, "cache ttl")
+
+	_ = time.Second
+}
@@ -0,0 +1,23 @@
+package floats
+
+import "time"
+
+type seconds float64
+
+func cases(secs float64, ratio float32, s seconds, n int, d time.Duration) {
+	_ = time.Duration(secs) * time.Second // want "Conversion of float `secs` to duration truncates it before scaling: use `time.Duration\\(secs \\* float64\\(time.Second\\)\\)`"
+
+	_ = time.Millisecond * time.Duration(ratio) // want "Conversion of float `ratio` to duration truncates it before scaling: use `time.Duration\\(float64\\(ratio\\) \\* float64\\(time.Millisecond\\)\\)`"
+
+	_ = time.Duration(s) * time.Minute // want "Conversion of float `s` to duration truncates it"
+
+	_ = time.Duration(secs*2) * d // want "Conversion of float `secs \\* 2` to duration truncates it before scaling: use `time.Duration\\(\\(secs \\* 2\\) \\* float64\\(d\\)\\)`"
+
+	_ = time.Duration(secs * float64(time.Second))
+
+	_ = time.Duration(n) * time.Second
+
+	_ = time.Duration(2.0) * time.Second
+
+	_ = time.Duration(secs) * 2
+}
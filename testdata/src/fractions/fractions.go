@@ -0,0 +1,23 @@
+package fractions
+
+import "time"
+
+const ratio = 3
+
+func cases(d time.Duration, n int) {
+	_ = 1 / 2 * d // want "Constant division `1 / 2` truncates to `0` before the duration arithmetic: multiply first or use float math"
+
+	_ = 3 / 10 * 10 * d // want "Constant division `3 / 10` truncates to `0`"
+
+	_ = time.Duration(7/ratio) * time.Second // want "Constant division `7 / ratio` truncates to `2`"
+
+	_ = d * 3 / 10
+
+	_ = 10 / 2 * d
+
+	_ = time.Second / 3
+
+	_ = n * (1 / 2)
+
+	_ = time.Duration(float64(d) * (1.0 / 2))
+}
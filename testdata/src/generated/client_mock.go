@@ -0,0 +1,7 @@
+package generated
+
+import "time"
+
+func mock(d time.Duration) {
+	_ = d * time.Second
+}
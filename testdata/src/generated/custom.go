@@ -0,0 +1,9 @@
+// Autogenerated by internal-gen v2, regenerate with `make gen`.
+
+package generated
+
+import "time"
+
+func custom(d time.Duration) {
+	_ = d * time.Second
+}
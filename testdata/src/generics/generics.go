@@ -0,0 +1,62 @@
+package generics
+
+import "time"
+
+type number interface {
+	~int | ~int64
+}
+
+type span interface {
+	comparable
+	time.Duration
+}
+
+func scale[T ~int | ~int32 | time.Duration](d time.Duration, n T) time.Duration {
+	return d * time.Duration(n) // want `Multiplication of durations: .d \* time.Duration\(n\).`
+}
+
+func count[T number](n T) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
+func mul[T int64 | time.Duration](a, b T) T {
+	return a * b
+}
+
+func double[T int64 | time.Duration](a T) T {
+	return a * 2
+}
+
+func convert[T int64 | time.Duration](a T, n int) T {
+	return a * T(n)
+}
+
+func square[T span](a T) T {
+	return a * a // want `Multiplication of durations: .a \* a.`
+}
+
+func sum[T int | time.Duration](values ...T) T {
+	var total T
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func cases(d time.Duration, n int64) {
+	_ = mul(d, time.Second) // want "Multiplication of durations in `mul` instantiated with time.Duration: `a \\* b`"
+
+	_ = mul[time.Duration](d, 2) // want "Multiplication of durations in `mul` instantiated with time.Duration"
+
+	_ = mul(n, 2)
+
+	_ = double(d)
+
+	_ = convert(d, 3)
+
+	_ = square(d)
+
+	_ = sum(d, time.Second)
+
+	_ = count(n)
+}
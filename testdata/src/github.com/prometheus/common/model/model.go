@@ -0,0 +1,8 @@
+package model
+
+import "time"
+
+// Duration wraps time.Duration
+type Duration time.Duration
+
+func (d Duration) String() string { return time.Duration(d).String() }
@@ -0,0 +1,15 @@
+package pflag
+
+import "time"
+
+type FlagSet struct{}
+
+func (f *FlagSet) Duration(name string, value time.Duration, usage string) *time.Duration { return nil }
+
+func (f *FlagSet) DurationP(name, shorthand string, value time.Duration, usage string) *time.Duration {
+	return nil
+}
+
+func DurationVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {}
+
+func (f *FlagSet) GetDuration(name string) (time.Duration, error) { return 0, nil }
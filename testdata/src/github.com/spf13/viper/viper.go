@@ -0,0 +1,9 @@
+package viper
+
+import "time"
+
+type Viper struct{}
+
+func (v *Viper) GetDuration(key string) time.Duration { return 0 }
+
+func GetDuration(key string) time.Duration { return 0 }
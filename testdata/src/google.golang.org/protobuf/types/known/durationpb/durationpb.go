@@ -0,0 +1,16 @@
+package durationpb
+
+import "time"
+
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func New(d time.Duration) *Duration { return nil }
+
+func (x *Duration) AsDuration() time.Duration { return 0 }
+
+func (x *Duration) GetSeconds() int64 { return x.Seconds }
+
+func (x *Duration) GetNanos() int32 { return x.Nanos }
@@ -0,0 +1,35 @@
+package helperapi
+
+import "time"
+
+// Seconds scales a number of seconds
+func Seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
+// Retries converts a number of retries, a count
+func Retries(n int) time.Duration {
+	return time.Duration(n)
+}
+
+// Backoff returns a count or a unit depending on the attempt
+func Backoff(attempt int, base time.Duration) time.Duration {
+	if attempt == 0 {
+		return time.Duration(attempt)
+	}
+
+	return base
+}
+
+type Config struct {
+	TimeoutSeconds int
+}
+
+// Timeout returns the timeout without its unit
+func (c Config) Timeout() time.Duration {
+	if c.TimeoutSeconds < 0 {
+		return 0
+	}
+
+	return time.Duration(c.TimeoutSeconds)
+}
@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"time"
+
+	"helperapi"
+)
+
+func attempts(n int) time.Duration {
+	return limit(n)
+}
+
+func limit(n int) time.Duration {
+	return time.Duration(n + 1)
+}
+
+func cases(cfg helperapi.Config, n int, retries time.Duration) {
+	_ = helperapi.Seconds(n) * retries // want `Multiplication of durations: .helperapi.Seconds\(n\) \* retries.`
+
+	_ = helperapi.Seconds(n) * time.Duration(n)
+
+	_ = helperapi.Retries(n) * time.Second
+
+	_ = helperapi.Backoff(n, time.Second) * time.Second // want `Multiplication of durations: .helperapi.Backoff\(n, time.Second\) \* time.Second.`
+
+	_ = cfg.Timeout() * time.Second
+
+	_ = time.Duration(cfg.Timeout()) * time.Millisecond
+
+	_ = attempts(n) * time.Second
+
+	_ = limit(n) * time.Second
+
+	_ = func() time.Duration { return time.Duration(n) }() * time.Second // want `Multiplication of durations`
+}
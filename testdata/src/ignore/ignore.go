@@ -0,0 +1,41 @@
+package ignore
+
+import "time"
+
+func cases(jitter, spread time.Duration) {
+	_ = jitter * spread //durationcheck:ignore
+
+	//durationcheck:ignore reason=squared jitter is intentional
+	_ = jitter * spread
+
+	_ = jitter * spread //durationcheck:ignore until=2099-12-31 reason=waiting for the clock refactoring
+
+	_ = jitter * spread //durationcheck:ignore until=2020-01-01 // want `Multiplication of durations: .* \(suppression expired on 2020-01-01\)`
+
+	_ = jitter * spread //durationcheck:ignore until=tomorrow // want `Multiplication of durations` `Invalid durationcheck:ignore directive: invalid until date "tomorrow"`
+
+	_ = jitter * spread //durationcheck:ignored // want `Multiplication of durations`
+
+	_ = jitter * spread // want `Multiplication of durations`
+}
+
+func statements(jitter, spread time.Duration) {
+	//durationcheck:ignore reason=the whole call is intentional
+	time.Sleep(
+		jitter * spread,
+	)
+
+	_ = []time.Duration{ //durationcheck:ignore
+		jitter * spread,
+		spread * jitter,
+	}
+
+	//durationcheck:ignore
+	go func() {
+		_ = jitter * spread // want `Multiplication of durations`
+	}()
+
+	time.Sleep(
+		jitter * spread, // want `Multiplication of durations`
+	)
+}
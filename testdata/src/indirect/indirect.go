@@ -0,0 +1,10 @@
+package indirect
+
+import "b"
+
+// the package doesn't import time but gets its durations from b
+func cases(n int) {
+	_ = b.SomeDuration * b.SomeDuration // want `Multiplication of durations`
+
+	_ = b.SomeInt * n
+}
@@ -0,0 +1,7 @@
+package v1
+
+import "time"
+
+type Duration struct {
+	time.Duration
+}
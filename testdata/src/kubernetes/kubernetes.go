@@ -0,0 +1,25 @@
+package kubernetes
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Spec struct {
+	Interval metav1.Duration
+}
+
+func cases(spec Spec, retries int) {
+	_ = metav1.Duration{Duration: 30} // want "metav1.Duration.Duration initialized with bare number `30` is 30 nanoseconds: multiply it by a unit"
+
+	_ = metav1.Duration{500} // want "metav1.Duration.Duration initialized with bare number `500`"
+
+	_ = &metav1.Duration{Duration: 30 * time.Second}
+
+	_ = metav1.Duration{Duration: 1}
+
+	_ = spec.Interval.Duration * time.Second // want `Multiplication of durations`
+
+	_ = spec.Interval.Duration * time.Duration(retries)
+}
@@ -0,0 +1,7 @@
+package long
+
+import "time"
+
+func cases(firstVeryLongDurationVariableName, secondVeryLongDurationVariableName time.Duration) {
+	_ = firstVeryLongDurationVariableName * secondVeryLongDurationVariableName // want "^Multiplication of durations: `firstVeryLongDurationVariableName \\* sec\\.\\.\\.`: left operand `firstVeryLongDurationVariableName` \\(time.Duration, parameter\\) and right operand `secondVeryLongDurationVariableName` \\(time.Duration, parameter\\) already carry units$"
+}
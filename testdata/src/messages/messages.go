@@ -0,0 +1,12 @@
+package messages
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second // want "^期間同士の乗算: `d \\* time.Second`：左オペランド`d` \\(time.Duration, パラメータ\\)はすでに単位を持つ$"
+
+	_ = d & 0xff // want "Bitwise operation on durations: `d & 0xff`"
+
+	//durationcheck:ignore until=2020-01-01
+	_ = d * time.Minute // want "期間同士の乗算: `d \\* time.Minute`：左オペランド`d` \\(time.Duration, パラメータ\\)はすでに単位を持つ（抑制の期限切れ: 2020-01-01）"
+}
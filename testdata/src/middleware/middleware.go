@@ -0,0 +1,9 @@
+package middleware
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second // want `Multiplication of durations: .d \* time.Second.: left operand .d. \(time.Duration, parameter\) already carries a unit \(see https://runbooks.example.com/mul\)`
+
+	_ = d & 0xff
+}
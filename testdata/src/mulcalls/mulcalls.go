@@ -0,0 +1,27 @@
+package mulcalls
+
+import (
+	"time"
+
+	"scaleapi"
+)
+
+func double(a, b time.Duration) time.Duration {
+	return scaleapi.Scale(b, a) * 2
+}
+
+func cases(timeout time.Duration, n int, p scaleapi.Policy) {
+	_ = scaleapi.Scale(timeout, time.Second) // want "Multiplication of durations in call to `Scale`: `timeout` and `time.Second` carry units and are multiplied as its parameters `d` and `factor`"
+
+	_ = scaleapi.Scale(timeout, time.Duration(n))
+
+	_ = scaleapi.Scale(time.Duration(n), time.Second)
+
+	_ = scaleapi.Stretch(time.Minute, n, timeout) // want "Multiplication of durations in call to `Stretch`: `time.Minute` and `timeout` carry units and are multiplied as its parameters `base` and `factor`"
+
+	_ = p.Grow(timeout, time.Millisecond) // want "Multiplication of durations in call to `Grow`"
+
+	_ = double(timeout, time.Second) // want "Multiplication of durations in call to `double`: `timeout` and `time.Second` carry units and are multiplied as its parameters `a` and `b`"
+
+	_ = scaleapi.Add(timeout, time.Second)
+}
@@ -0,0 +1,17 @@
+package mulfix
+
+import "time"
+
+type config struct {
+	interval time.Duration
+}
+
+func cases(cfg config, timeout, d time.Duration) {
+	_ = cfg.interval * time.Second // want "Multiplication of durations: `cfg.interval \\* time.Second`"
+
+	_ = time.Millisecond * (timeout + 1) // want "Multiplication of durations"
+
+	_ = d * timeout // want "Multiplication of durations"
+
+	_ = time.Duration(int64(timeout)) * time.Second // want "Multiplication of durations"
+}
@@ -0,0 +1,30 @@
+package names
+
+import "time"
+
+type server struct {
+	port    int
+	timeout int
+}
+
+func cases() {
+	port := 8080
+	userID := 42
+	count := 3
+	retries := 3
+	srv := server{port: 8080, timeout: 10}
+
+	_ = time.Duration(port) * time.Second // want `Conversion of non-time value`
+
+	_ = time.Second * time.Duration(userID) // want `Conversion of non-time value`
+
+	_ = time.Duration(count) * time.Millisecond // want `Conversion of non-time value`
+
+	_ = time.Duration(srv.port) + time.Second // want `Conversion of non-time value`
+
+	_ = time.Duration(retries) * time.Second
+
+	_ = time.Duration(srv.timeout) * time.Second
+
+	_ = time.Duration(port)
+}
@@ -0,0 +1,27 @@
+package nested
+
+import "time"
+
+type millis int64
+
+func validCases(n int, ms int64, f float64) {
+	_ = time.Duration(int64(n)) * time.Second
+
+	_ = time.Duration(uint64(ms)) * time.Millisecond
+
+	_ = time.Duration(int64(float64(f))) * time.Second
+
+	_ = time.Duration(millis(ms)) * time.Millisecond
+}
+
+func invalidCases(d time.Duration) {
+	_ = time.Duration(int64(d)) * time.Second // want `Multiplication of durations: .time.Duration\(int64\(d\)\) \* time.Second.`
+
+	_ = time.Duration(int(d)) * time.Millisecond // want `Multiplication of durations: .time.Duration\(int\(d\)\) \* time.Millisecond.`
+
+	_ = time.Duration(uint64(int64(d))) * time.Second // want `Multiplication of durations: .time.Duration\(uint64\(int64\(d\)\)\) \* time.Second.`
+
+	_ = time.Duration(float64(d)*1.5) * time.Second // want `Multiplication of durations: .time.Duration\(float64\(d\)\*1.5\) \* time.Second.`
+
+	_ = time.Duration(millis(d)) * time.Millisecond // want `Multiplication of durations: .time.Duration\(millis\(d\)\) \* time.Millisecond.`
+}
@@ -0,0 +1,20 @@
+package nolint
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second //nolint:durationcheck
+
+	_ = d * time.Second //nolint:gosec,durationcheck // squared on purpose
+
+	//nolint:all
+	_ = d * time.Second
+
+	_ = d * time.Second //nolint
+
+	_ = d * time.Second //nolint:gosec // want `Multiplication of durations`
+
+	_ = d * time.Second //nolintx // want `Multiplication of durations`
+
+	_ = d * 2 //nolint:durationcheck
+}
@@ -0,0 +1,12 @@
+package nolintdirective
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second //nolint:durationcheck // squared on purpose
+
+	//nolint:durationcheck
+	_ = d * time.Second
+
+	_ = d * 2 //nolint:durationcheck // want `Unused durationcheck:ignore directive`
+}
@@ -0,0 +1,19 @@
+package overflow
+
+import "time"
+
+const days = 200000
+
+func cases(n int, d time.Duration) {
+	_ = time.Duration(n) * time.Hour * 3000000 // want "Duration `time.Duration\\(n\\) \\* time.Hour \\* 3000000` overflows: its constant factors `time.Hour \\* 3000000` alone amount to 10800000000000000000 nanoseconds, beyond the range of time.Duration"
+
+	_ = time.Hour * time.Duration(n) * days * 24 // want "its constant factors `time.Hour \\* days \\* 24` alone amount to"
+
+	_ = -time.Duration(n) * (time.Hour * 1000) * -3000 // want "its constant factors `time.Hour \\* 1000 \\* -3000` alone amount to -10800000000000000000 nanoseconds"
+
+	_ = time.Duration(n) * time.Hour * 24 * 365
+
+	_ = d * 2 * 3
+
+	_ = time.Duration(n) * time.Hour
+}
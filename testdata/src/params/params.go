@@ -0,0 +1,23 @@
+package params
+
+import "time"
+
+type Client struct{}
+
+type client struct{}
+
+func Dial(addr string, timeoutMs int) {} // want "Integer parameter `timeoutMs` of exported function `Dial` looks like a duration: use time.Duration"
+
+func (c *Client) SetTTL(ttl int64) {} // want "Integer parameter `ttl` of exported function `SetTTL` looks like a duration"
+
+func Poll(interval, retries uint) {} // want "Integer parameter `interval` of exported function `Poll` looks like a duration"
+
+func (c client) SetTTL(ttl int64) {}
+
+func dial(timeout int) {}
+
+func Retry(count int, name string) {}
+
+func Sleep(wait float64) {}
+
+func Connect(addr string, timeout time.Duration) {}
@@ -0,0 +1,28 @@
+package parens
+
+import "time"
+
+func validCases(n int) {
+	_ = (time.Duration(n)) * time.Second
+
+	_ = time.Duration(n) * (time.Second)
+
+	_ = -time.Duration(n) * time.Second
+
+	_ = time.Duration((n)) * time.Millisecond
+
+	_ = (2 * time.Duration(n)) * time.Second
+
+	_ = +time.Duration(n+1) * time.Hour
+}
+
+func invalidCases(a, b time.Duration) {
+	_ = (a) * (time.Second) // want `Multiplication of durations: .\(a\) \* \(time.Second\).`
+
+	_ = -a * time.Second // want `Multiplication of durations: .-a \* time.Second.`
+
+	_ = time.Duration(-a) * time.Second // want `Multiplication of durations: .time.Duration\(-a\) \* time.Second.`
+
+	// a single diagnostic for the whole chain
+	_ = (a * b) * time.Second // want `Multiplication of durations: .\(a \* b\) \* time.Second.`
+}
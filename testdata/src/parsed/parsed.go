@@ -0,0 +1,61 @@
+package parsed
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	timeout = flag.Duration("timeout", 30*time.Second, "")
+	wait    time.Duration
+)
+
+type config struct {
+	interval time.Duration
+}
+
+func init() {
+	flag.DurationVar(&wait, "wait", time.Minute, "")
+}
+
+func cases(s string, fs *pflag.FlagSet, v *viper.Viper, cfg config, n int) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return
+	}
+
+	_ = d * time.Second // want "Duration `d` parsed by time.ParseDuration already carries a unit: multiplying it by time.Second rescales it"
+
+	_ = time.Millisecond * d // want "Duration `d` parsed by time.ParseDuration already carries a unit: multiplying it by time.Millisecond rescales it"
+
+	_ = d * 2 * time.Minute // want "Duration `d` parsed by time.ParseDuration already carries a unit: multiplying it by time.Minute rescales it"
+
+	_ = time.Duration(d) * time.Second // want "Duration `time.Duration\\(d\\)` parsed by time.ParseDuration already carries a unit"
+
+	_ = *timeout * time.Second // want "Duration `\\*timeout` parsed by flag.Duration already carries a unit"
+
+	_ = wait * time.Second // want "Duration `wait` parsed by flag.DurationVar already carries a unit"
+
+	_ = viper.GetDuration("ttl") * time.Second // want "Duration `viper.GetDuration\\(\"ttl\"\\)` parsed by viper.GetDuration already carries a unit"
+
+	_ = v.GetDuration("ttl") * time.Hour // want "Duration `v.GetDuration\\(\"ttl\"\\)` parsed by viper.GetDuration already carries a unit"
+
+	grace, _ := fs.GetDuration("grace")
+	_ = grace * time.Second // want "Duration `grace` parsed by pflag.GetDuration already carries a unit"
+
+	d *= time.Second // want "Duration `d` parsed by time.ParseDuration already carries a unit: multiplying it by time.Second rescales it"
+
+	// scaling by a count and dividing into a count are fine
+	_ = d * time.Duration(n)
+	_ = d / time.Second
+
+	// durations that aren't only parsed aren't reported by parsed-rescale
+	e, _ := time.ParseDuration(s)
+	e = cfg.interval
+	_ = e * time.Second // want "Multiplication of durations"
+
+	_ = cfg.interval * time.Second // want "Multiplication of durations"
+}
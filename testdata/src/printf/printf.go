@@ -0,0 +1,39 @@
+package printf
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+type logger struct{}
+
+func (logger) Infof(format string, args ...interface{}) {}
+
+func (logger) Send(topic string, args ...interface{}) {}
+
+func cases(d, timeout time.Duration, l logger, n int) {
+	_ = fmt.Sprintf("took %d", d) // want "Duration `d` formatted with `%d` prints a count of nanoseconds: use `%v`"
+
+	_ = fmt.Sprintf("%d ms", d) // want "Duration `d` formatted with `%d` prints a count of nanoseconds, not of milliseconds: use `d.Milliseconds\\(\\)`"
+
+	log.Printf("%d: waited %ds", n, timeout) // want "Duration `timeout` formatted with `%d` prints a count of nanoseconds, not of seconds: use `int64\\(timeout.Seconds\\(\\)\\)`"
+
+	l.Infof("%*d %x", 4, d, d*2) // want "Duration `d` formatted with `%d`" "Duration `d \\* 2` formatted with `%x`"
+
+	_ = fmt.Errorf("timeout after %d\tMicroseconds", d) // want "Duration `d` formatted with `%d` prints a count of nanoseconds, not of microseconds: use `d.Microseconds\\(\\)`"
+
+	_ = fmt.Sprintf("%d%% in %d minutes", n, d+timeout) // want "use `int64\\(\\(d \\+ timeout\\).Minutes\\(\\)\\)`"
+
+	_ = fmt.Sprintf("took %d\n", d) // want "Duration `d` formatted with `%d` prints a count of nanoseconds: use `%v`"
+
+	_ = fmt.Sprintf("%d ns", d)
+
+	_ = fmt.Sprintf("%v %s", d, d)
+
+	_ = fmt.Sprintf("%[1]d", d)
+
+	_ = fmt.Sprintf("%d", d.Milliseconds())
+
+	l.Send("%d", d)
+}
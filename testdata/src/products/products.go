@@ -0,0 +1,24 @@
+package products
+
+import "time"
+
+func validCases(n, retries int, d time.Duration) {
+	_ = time.Duration(n) * time.Duration(retries) * time.Second
+
+	_ = 2 * time.Duration(n) * time.Second
+
+	_ = time.Second * 2 * time.Duration(retries)
+
+	_ = d * 2 * 3
+}
+
+func invalidCases(a, b, factor time.Duration, n int) {
+	// a single diagnostic for the whole chain
+	_ = a * b * time.Second // want `Multiplication of durations: .a \* b \* time.Second.`
+
+	_ = time.Duration(n) * time.Second * factor // want `Multiplication of durations: .time.Duration\(n\) \* time.Second \* factor.`
+
+	_ = a * 2 * time.Minute // want `Multiplication of durations: .a \* 2 \* time.Minute.`
+
+	_ = 3 * a * b * 4 // want `Multiplication of durations: .3 \* a \* b \* 4.`
+}
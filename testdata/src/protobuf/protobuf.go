@@ -0,0 +1,31 @@
+package protobuf
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type request struct {
+	Timeout *durationpb.Duration
+}
+
+func cases(req *request, pb durationpb.Duration, n int, d time.Duration) {
+	_ = time.Duration(req.Timeout.Seconds)*time.Second + time.Duration(req.Timeout.Nanos) // want "Manual conversion of durationpb fields to duration: use `req.Timeout.AsDuration\\(\\)`" "Manual conversion of durationpb fields"
+
+	_ = time.Duration(pb.GetSeconds()*1e9 + int64(pb.GetNanos())) // want "use `pb.AsDuration\\(\\)`"
+
+	_ = req.Timeout.AsDuration() * time.Second // want `Multiplication of durations`
+
+	_ = req.Timeout.AsDuration() * 2
+
+	_ = durationpb.New(30) // want "Count `30` passed to durationpb.New is in nanoseconds: multiply it by a unit"
+
+	_ = durationpb.New(time.Duration(n)) // want "Count `time.Duration\\(n\\)` passed to durationpb.New"
+
+	_ = durationpb.New(0)
+
+	_ = durationpb.New(30 * time.Second)
+
+	_ = durationpb.New(d)
+}
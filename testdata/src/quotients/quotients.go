@@ -0,0 +1,33 @@
+package quotients
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+func wait(d time.Duration) {}
+
+func cases(ctx context.Context, total, interval time.Duration, n int) {
+	time.Sleep(total / interval) // want "Quotient of durations `total / interval` is a count, not a duration: passed to time.Sleep"
+
+	ratio := total / interval
+	_, cancel := context.WithTimeout(ctx, ratio) // want "`ratio` holds the quotient of durations `total / interval`, a count, not a duration: passed to context.WithTimeout"
+	defer cancel()
+
+	wait(time.Hour / time.Minute) // want "Quotient of durations `time.Hour / time.Minute` is a count, not a duration: passed to quotients.wait"
+
+	_ = http.Client{Timeout: (total / interval)} // want "Quotient of durations `total / interval` is a count, not a duration: assigned to field Timeout"
+
+	// a duration divided by a count is a duration
+	time.Sleep(total / time.Duration(n))
+	time.Sleep(total / 2)
+
+	// the count isn't used as a duration
+	_ = int64(total / interval)
+
+	// reassigned, the variable may hold a duration
+	step := total / interval
+	step = interval
+	time.Sleep(step)
+}
@@ -0,0 +1,43 @@
+package rawint
+
+import "time"
+
+type config struct {
+	TimeoutSeconds int64
+	IntervalMs     int64
+	Retries        int
+}
+
+const maxWait = 30
+
+func cases(d, elapsed time.Duration, cfg *config) bool {
+	if int64(d) > 30 { // want "Duration `d` converted to `int64` counts nanoseconds but is compared to `30`: convert a count of seconds or milliseconds, e.g. `int64\\(d / time.Second\\)`"
+		return true
+	}
+
+	_ = maxWait <= int(elapsed+d) // want "Duration `elapsed \\+ d` converted to `int` counts nanoseconds but is compared to `maxWait`"
+
+	cfg.TimeoutSeconds = int64(d) // want "Duration `d` converted to `int64` counts nanoseconds but is stored in `TimeoutSeconds`: use `int64\\(d / time.Second\\)`"
+
+	timeoutMs := int64(d) // want "Duration `d` converted to `int64` counts nanoseconds but is stored in `timeoutMs`: use `d.Milliseconds\\(\\)`"
+
+	var waitMinutes = int(d) // want "stored in `waitMinutes`: use `int\\(d / time.Minute\\)`"
+
+	_ = config{IntervalMs: int64(elapsed)} // want "stored in `IntervalMs`: use `elapsed.Milliseconds\\(\\)`"
+
+	_ = config{Retries: int(d)}
+
+	_ = int64(d) > 0
+
+	_ = int64(d) > 5000000
+
+	_ = int64(d) > 1
+
+	_ = int64(d/time.Second) > 30
+
+	_ = int(d/elapsed) < 10
+
+	total := int64(d)
+
+	return timeoutMs > 0 && total > 0 && waitMinutes > 0
+}
@@ -0,0 +1,14 @@
+package reason
+
+import "time"
+
+func cases(jitter, spread time.Duration) {
+	_ = jitter * spread //durationcheck:ignore reason=squared jitter is intentional
+
+	//durationcheck:ignore until=2099-12-31 reason=waiting for the clock refactoring
+	_ = jitter * spread
+
+	_ = jitter * spread //durationcheck:ignore // want `Multiplication of durations` `Invalid durationcheck:ignore directive: a reason=... is required`
+
+	_ = jitter * spread //durationcheck:ignore reason= // want `Multiplication of durations` `Invalid durationcheck:ignore directive: a reason=... is required`
+}
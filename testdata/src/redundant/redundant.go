@@ -0,0 +1,31 @@
+package redundant
+
+import "time"
+
+type config struct {
+	timeout time.Duration
+}
+
+func cases(d time.Duration, cfg config, ns int64) {
+	_ = d * time.Nanosecond // want "Multiplication of duration `d` by time.Nanosecond is redundant: `d` already is a duration"
+
+	_ = time.Nanosecond * cfg.timeout // want "Multiplication of duration `cfg.timeout` by time.Nanosecond is redundant"
+
+	_ = d / time.Nanosecond // want "Division of duration `d` by time.Nanosecond is redundant: `d` already is a duration"
+
+	_ = int64(d / time.Nanosecond) // want "Division of duration `d` by time.Nanosecond is redundant: use `d.Nanoseconds\\(\\)`"
+
+	_ = int((cfg.timeout + d) / time.Nanosecond) // want "Division of duration `\\(cfg.timeout \\+ d\\)` by time.Nanosecond is redundant: use `\\(cfg.timeout \\+ d\\).Nanoseconds\\(\\)`"
+
+	d *= time.Nanosecond // want "Multiplication of duration `d` by time.Nanosecond is redundant"
+
+	d /= time.Nanosecond // want "Division of duration `d` by time.Nanosecond is redundant"
+
+	_ = time.Duration(ns) * time.Nanosecond
+
+	_ = time.Nanosecond * 10
+
+	_ = d / time.Millisecond
+
+	_ = float64(d / time.Nanosecond) // want "Division of duration `d` by time.Nanosecond is redundant"
+}
@@ -0,0 +1,20 @@
+package related
+
+import "time"
+
+type config struct {
+	Backoff time.Duration
+}
+
+func jitter() time.Duration {
+	return time.Millisecond
+}
+
+func cases(start time.Time, timeout time.Duration, cfg config) {
+	elapsed := time.Since(start)
+	_ = elapsed * time.Second // want `Multiplication of durations: .elapsed \* time.Second.: left operand .elapsed. \(time.Duration, local variable\) already carries a unit$`
+
+	_ = timeout * cfg.Backoff // want `Multiplication of durations: .timeout \* cfg.Backoff.: left operand .timeout. \(time.Duration, parameter\) and right operand .cfg.Backoff. \(time.Duration, field\) already carry units$`
+
+	_ = jitter() * time.Duration(timeout) // want `Multiplication of durations: .jitter\(\) \* time.Duration\(timeout\).: left operand .jitter\(\). \(time.Duration, returned by .jitter.\) and right operand .time.Duration\(timeout\). \(time.Duration, parameter\) already carry units$`
+}
@@ -0,0 +1,19 @@
+package renamed
+
+import (
+	t "time"
+)
+
+func validCases(n int) {
+	_ = t.Duration(10) * t.Second
+
+	_ = t.Second * t.Duration(n)
+
+	_ = t.Duration(n+1) * t.Millisecond
+}
+
+func invalidCases(d t.Duration) {
+	_ = d * t.Second // want `Multiplication of durations: .d \* t.Second.`
+
+	_ = t.Duration(d) * t.Second // want `Multiplication of durations: .t.Duration\(d\) \* t.Second.`
+}
@@ -0,0 +1,11 @@
+package result
+
+import "time"
+
+func products(n int, timeout time.Duration) {
+	_ = time.Duration(n) * timeout * time.Second // want "Multiplication of durations"
+
+	timeout *= time.Minute // want "Multiplication of durations in compound assignment"
+
+	_ = time.Duration(n) * time.Second
+}
@@ -0,0 +1,57 @@
+package returns
+
+import "time"
+
+var d = 10 * time.Second
+
+func Timeout() int64 {
+	return int64(d) // want "Duration returned as int64 loses its unit: declare the result of `Timeout` as time.Duration"
+}
+
+func TimeoutAndError() (int, error) {
+	return int(d), nil // want "Duration returned as int loses its unit"
+}
+
+func Millis() int64 {
+	return d.Milliseconds()
+}
+
+func Nanos() int64 {
+	return d.Nanoseconds()
+}
+
+func Seconds() int {
+	return int(d.Seconds())
+}
+
+// the names state the unit of the results
+func TimeoutNanos() int64 {
+	return int64(d)
+}
+
+func (c client) TimeoutNs() int64 {
+	return int64(c.timeout)
+}
+
+// int64(d) counts nanoseconds, not the milliseconds the name states
+func TimeoutMs() int64 {
+	return int64(d) // want "Duration returned as int64 loses its unit: declare the result of `TimeoutMs` as time.Duration"
+}
+
+type client struct {
+	timeout time.Duration
+}
+
+func Float() float64 {
+	return float64(d)
+}
+
+func Duration() time.Duration {
+	return time.Duration(d)
+}
+
+func Literal() {
+	_ = func() int64 {
+		return int64(d) // want "Duration returned as int64 loses its unit: declare the result as time.Duration"
+	}
+}
@@ -0,0 +1,15 @@
+package roundtrip
+
+import "time"
+
+func integers(d time.Duration, ms int64) {
+	_ = time.Duration(d.Milliseconds()) * time.Millisecond // want "Round trip of duration `d` through Milliseconds truncates it: use `d` or `d.Truncate\\(time.Millisecond\\)`"
+
+	_ = time.Microsecond * time.Duration(d.Microseconds()) // want "Round trip of duration `d` through Microseconds truncates it"
+
+	_ = time.Duration(d.Nanoseconds()) * time.Nanosecond // want "Round trip of duration `d` through Nanoseconds is redundant: use `d`"
+
+	_ = time.Duration(d.Milliseconds()) * time.Second // want "Duration `d` converted to Milliseconds is scaled by time.Second: the units don't match, use `d`"
+
+	_ = time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,21 @@
+package roundtrip
+
+import "time"
+
+type job struct {
+	timeout time.Duration
+}
+
+func cases(d time.Duration, j job, start time.Time) {
+	_ = time.Duration(d.Seconds()) * time.Second // want "Round trip of duration `d` through Seconds truncates it: use `d` or `d.Truncate\\(time.Second\\)`"
+
+	_ = time.Minute * time.Duration(int64(j.timeout.Minutes())) // want "Round trip of duration `j.timeout` through Minutes truncates it"
+
+	_ = time.Duration((d + time.Second).Hours()) * time.Hour // want "Round trip of duration `d \\+ time.Second` through Hours truncates it: use `d \\+ time.Second` or `\\(d \\+ time.Second\\).Truncate\\(time.Hour\\)`"
+
+	_ = time.Duration(d.Seconds()) * time.Millisecond // want "Duration `d` converted to Seconds is scaled by time.Millisecond: the units don't match, use `d`"
+
+	_ = time.Duration(d.Seconds() * float64(time.Second))
+
+	_ = time.Duration(time.Since(start).Seconds()*1.5) * time.Second
+}
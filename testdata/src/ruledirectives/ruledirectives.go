@@ -0,0 +1,9 @@
+package ruledirectives
+
+import "time"
+
+func cases(jitter, spread time.Duration) {
+	_ = jitter * spread //durationcheck:ignore reason=squared jitter is intentional
+
+	_ = jitter + spread //durationcheck:ignore until=tomorrow // want `Invalid durationcheck:ignore directive: invalid until date "tomorrow"`
+}
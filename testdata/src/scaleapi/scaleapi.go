@@ -0,0 +1,27 @@
+package scaleapi
+
+import "time"
+
+// Scale multiplies a duration by a factor, expected to be a count
+func Scale(d, factor time.Duration) time.Duration {
+	return d * factor
+}
+
+// Stretch multiplies its durations through Scale
+func Stretch(base time.Duration, attempts int, factor time.Duration) time.Duration {
+	return Scale(base, factor) + time.Duration(attempts)
+}
+
+// Policy grows intervals
+type Policy struct{}
+
+// Grow multiplies the interval in place
+func (Policy) Grow(interval, factor time.Duration) time.Duration {
+	interval *= factor
+	return interval
+}
+
+// Add doesn't multiply its durations
+func Add(a, b time.Duration) time.Duration {
+	return a + b
+}
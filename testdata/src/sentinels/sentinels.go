@@ -0,0 +1,27 @@
+package sentinels
+
+import (
+	"math"
+	"time"
+)
+
+const forever = time.Duration(math.MaxInt64)
+
+func cases(jitter time.Duration, n int) {
+	_ = forever + jitter // want "Arithmetic on the math.MaxInt64 sentinel duration `forever` overflows: `forever \\+ jitter`"
+
+	_ = jitter + time.Duration(math.MaxInt64) // want "sentinel duration `time.Duration\\(math.MaxInt64\\)`"
+
+	_ = forever * time.Duration(n) // want "Arithmetic on the math.MaxInt64 sentinel duration"
+
+	timeout := time.Second
+	timeout += forever // want "Arithmetic on the math.MaxInt64 sentinel duration `forever` overflows: `timeout \\+= forever`"
+
+	_ = forever - jitter
+
+	_ = forever / 2
+
+	_ = time.Duration(math.MaxInt32) + jitter
+
+	_ = forever + 0 - jitter
+}
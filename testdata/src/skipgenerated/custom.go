@@ -0,0 +1,10 @@
+// Autogenerated by internal-gen v2, regenerate with `make gen`.
+
+package skipgenerated
+
+import "time"
+
+// headers of other generators are only recognized by the configuration
+func custom(d time.Duration) {
+	_ = d * time.Second // want `Multiplication of durations`
+}
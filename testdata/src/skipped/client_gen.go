@@ -0,0 +1,7 @@
+package skipped
+
+import "time"
+
+func generatedDelay(d time.Duration) time.Duration {
+	return d * time.Second
+}
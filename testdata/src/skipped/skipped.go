@@ -0,0 +1,7 @@
+package skipped
+
+import "time"
+
+func delay(d time.Duration) time.Duration {
+	return d * time.Second // want `Multiplication of durations`
+}
@@ -0,0 +1,7 @@
+package lib
+
+import "time"
+
+func Delay(d time.Duration) time.Duration {
+	return d * time.Second
+}
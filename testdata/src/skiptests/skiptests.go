@@ -0,0 +1,7 @@
+package skiptests
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second // want `Multiplication of durations`
+}
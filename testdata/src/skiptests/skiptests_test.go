@@ -0,0 +1,7 @@
+package skiptests
+
+import "time"
+
+func testCases(d time.Duration) {
+	_ = d * time.Millisecond
+}
@@ -0,0 +1,34 @@
+package sleeps
+
+import (
+	"context"
+	"time"
+)
+
+const retryDelay = 250
+
+func cases(ctx context.Context, n int, ms int64, d time.Duration) {
+	time.Sleep(5) // want "Argument `5` of time.Sleep is 5 nanoseconds: multiply it by a unit"
+
+	<-time.After(retryDelay) // want "Argument `retryDelay` of time.After is 250 nanoseconds"
+
+	_, cancel := context.WithTimeout(ctx, 500) // want "Argument `500` of context.WithTimeout is 500 nanoseconds"
+	defer cancel()
+
+	time.Sleep(time.Duration(n)) // want "Argument `time.Duration\\(n\\)` of time.Sleep is a count of nanoseconds: multiply it by a unit"
+
+	_ = time.AfterFunc(2*60, func() {}) // want "Argument `2 \\* 60` of time.AfterFunc is 120 nanoseconds"
+
+	time.Sleep(0)
+
+	time.Sleep(1)
+
+	time.Sleep(d)
+
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+
+	<-time.After(5 * time.Second)
+
+	_, cancel = context.WithTimeout(ctx, d/2)
+	defer cancel()
+}
@@ -0,0 +1,30 @@
+package sleeps
+
+import (
+	"net"
+	"time"
+)
+
+func tickers(conn net.Conn, n int, d time.Duration, timer *time.Timer) {
+	ticker := time.NewTicker(100) // want "Argument `100` of time.NewTicker is 100 nanoseconds"
+	defer ticker.Stop()
+
+	ticker.Reset(time.Duration(n) * 2) // want "Argument `time.Duration\\(n\\) \\* 2` of time.Ticker.Reset is a count of nanoseconds"
+
+	_ = time.NewTimer(time.Duration(n)) // want "Argument `time.Duration\\(n\\)` of time.NewTimer is a count of nanoseconds"
+
+	timer.Reset(30) // want "Argument `30` of time.Timer.Reset is 30 nanoseconds"
+
+	_ = conn.SetDeadline(time.Now().Add(30)) // want "Argument `30` of time.Time.Add is 30 nanoseconds"
+
+	for range time.Tick(time.Duration(n) * time.Second) {
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(d))
+
+	_ = time.Now().Add(-1)
+
+	var nsDelta int64
+	_ = time.Now().Add(time.Duration(nsDelta))
+	_ = time.Now().Add(time.Duration(-n))
+}
@@ -0,0 +1,19 @@
+package strict
+
+import "time"
+
+const attempts = 3
+
+func multiplications(n int, d time.Duration) {
+	_ = time.Duration(n) * time.Second // want `Multiplication of durations in strict mode: .time.Duration\(n\) \* time.Second.`
+	_ = time.Second * time.Duration(5) // want `Multiplication of durations in strict mode: .time.Second \* time.Duration\(5\).`
+	_ = d * time.Second                // want `Multiplication of durations: .d \* time.Second.`
+
+	d *= time.Duration(n) // want `Multiplication of durations in strict mode: .d \*= time.Duration\(n\).`
+	d *= 2
+
+	_ = 2 * time.Second
+	_ = attempts * time.Second
+	_ = (attempts + 1) * time.Millisecond
+	_ = time.Duration(n) * 10
+}
@@ -0,0 +1,32 @@
+package suffixes
+
+import "time"
+
+type config struct {
+	IntervalMs  int
+	RetryMillis int64
+}
+
+func cases(timeoutSeconds int, cfg config, delaySecs float64, waitMinutes, n int, base time.Duration) {
+	_ = time.Duration(timeoutSeconds) // want "Conversion of `timeoutSeconds`, named in seconds, to duration isn't scaled: multiply it by time.Second"
+
+	time.Sleep(time.Duration(cfg.IntervalMs)) // want "Conversion of `IntervalMs`, named in milliseconds, to duration isn't scaled: multiply it by time.Millisecond"
+
+	_ = time.Duration(cfg.RetryMillis) * 2 // want "Conversion of `RetryMillis`, named in milliseconds, to duration isn't scaled"
+
+	_ = -time.Duration(waitMinutes) // want "Conversion of `waitMinutes`, named in minutes, to duration isn't scaled"
+
+	_ = time.Duration(cfg.IntervalMs) * time.Second // want "Conversion of `IntervalMs`, named in milliseconds, to duration is scaled by time.Second: multiply it by time.Millisecond instead"
+
+	_ = time.Duration(timeoutSeconds) * time.Second
+
+	_ = 2 * (time.Duration(cfg.IntervalMs) * time.Millisecond)
+
+	_ = time.Duration(delaySecs) * time.Second
+
+	_ = time.Duration(waitMinutes) * base
+
+	_ = time.Duration(n)
+
+	_ = base / time.Duration(waitMinutes)
+}
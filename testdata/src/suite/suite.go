@@ -0,0 +1,11 @@
+package suite
+
+import "time"
+
+func cases(d, timeout time.Duration, mask time.Duration) {
+	_ = d & mask // want "Bitwise operation on durations"
+
+	_ = d * timeout
+
+	_ = d * time.Nanosecond
+}
@@ -0,0 +1,41 @@
+package tags
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Config struct {
+	Timeout  time.Duration  `json:"timeout"`    // want "Duration field `Timeout` has a json tag: it is encoded as a count of nanoseconds and can't be decoded from strings like \"30s\", use a duration type encoded as a string"
+	Interval *time.Duration `yaml:"interval"`   // want "Duration field `Interval` has a yaml tag"
+	Both     time.Duration  `json:"b" yaml:"b"` // want "Duration field `Both` has a json tag"
+	Ignored  time.Duration  `json:"-"`
+	Untagged time.Duration
+	private  time.Duration `json:"private"`
+	Retries  int           `json:"retries"`
+}
+
+type Custom struct {
+	Timeout time.Duration `json:"timeout"`
+	Backoff time.Duration `yaml:"backoff"` // want "Duration field `Backoff` has a yaml tag"
+}
+
+func (c *Custom) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+type Wrapped struct {
+	Timeout Duration `json:"timeout"`
+}
+
+func use(c Config) time.Duration {
+	return c.private
+}
@@ -0,0 +1,9 @@
+package tests
+
+import "time"
+
+func cases(d time.Duration, port int) {
+	_ = d & 0xff
+
+	_ = time.Duration(port) * time.Second // want `Conversion of non-time value`
+}
@@ -0,0 +1,9 @@
+package tests
+
+import "time"
+
+func testCases(d time.Duration, port int) {
+	_ = d & 0xff // want `Bitwise operation on durations`
+
+	_ = time.Duration(port) * time.Second
+}
@@ -0,0 +1,7 @@
+package testsonly
+
+import "time"
+
+func cases(d time.Duration) {
+	_ = d * time.Second
+}
@@ -0,0 +1,7 @@
+package testsonly
+
+import "time"
+
+func testCases(d time.Duration) {
+	_ = d * time.Second // want `Multiplication of durations`
+}
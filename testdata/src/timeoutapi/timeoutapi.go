@@ -0,0 +1,23 @@
+package timeoutapi
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func Dial(addr string, timeoutMs int) {}
+
+func Connect(addr string, timeout time.Duration) {}
+
+func Scrape(target string, timeout model.Duration) {}
+
+func Retry(attempts int, backoff int64) {}
+
+type Client struct{}
+
+func (Client) Expire(key string, ttlSecs int) {}
+
+func Resize(width, height int) {}
+
+func dial(timeoutMs int) {}
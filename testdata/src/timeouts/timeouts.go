@@ -0,0 +1,15 @@
+package timeouts
+
+import "timeoutapi"
+
+func cases(c timeoutapi.Client) {
+	timeoutapi.Dial("localhost:80", 500) // want "`Dial` expects milliseconds as int for `timeoutMs`: consider a Duration-based wrapper"
+
+	timeoutapi.Connect("localhost:80", 500)
+
+	timeoutapi.Retry(3, 100) // want "`Retry` expects an integer timeout `backoff` in an unspecified unit: consider a Duration-based wrapper"
+
+	c.Expire("key", 60) // want "`Expire` expects seconds as int for `ttlSecs`"
+
+	timeoutapi.Resize(1, 2)
+}
@@ -0,0 +1,10 @@
+package timeouttypes
+
+import "timeoutapi"
+
+func cases() {
+	// model.Duration is a duration type with -duration-types
+	timeoutapi.Scrape("localhost:9090", 30)
+
+	timeoutapi.Dial("localhost:80", 500) // want "`Dial` expects milliseconds as int for `timeoutMs`"
+}
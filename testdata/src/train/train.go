@@ -0,0 +1,23 @@
+package train
+
+import "time"
+
+type Clock struct{}
+
+func (Clock) Skew() time.Duration { return time.Millisecond }
+
+func jitter() time.Duration { return time.Millisecond }
+
+func cases(c Clock, backoff, retry, d time.Duration) {
+	_ = backoff * time.Second
+
+	_ = jitter() * d
+
+	_ = d * c.Skew()
+
+	_ = retry * time.Second
+
+	_ = retry * time.Hour
+
+	_ = d * d
+}
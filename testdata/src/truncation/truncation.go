@@ -0,0 +1,21 @@
+package truncation
+
+import "time"
+
+type config struct {
+	interval time.Duration
+}
+
+func interval() time.Duration { return time.Minute }
+
+func cases(elapsed, step time.Duration, cfg config) {
+	_ = (elapsed / time.Second) * time.Second
+	_ = time.Millisecond * (elapsed / time.Millisecond)
+	_ = (elapsed / step) * step
+	_ = (elapsed / cfg.interval) * cfg.interval
+
+	_ = (elapsed / time.Second) * time.Millisecond // want "Multiplication of durations"
+	_ = (elapsed / step) * time.Second             // want "Multiplication of durations"
+	_ = (elapsed / interval()) * interval()        // want "Multiplication of durations"
+	_ = (elapsed / time.Second) * time.Second * 2  // want "Multiplication of durations"
+}
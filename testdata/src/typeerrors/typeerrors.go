@@ -0,0 +1,32 @@
+package typeerrors
+
+import "time"
+
+// the package doesn't compile, as the buffers that editors analyze while they're being typed
+
+func Delay(n int) time.Duration {
+	return time.Duration(n+undefinedThing) * time.Second
+}
+
+func Scale(d time.Duration) time.Duration {
+	return d * missing.Timeout
+}
+
+func Wait(d time.Duration) {
+	time.Sleep(undefinedDelay)
+	time.Sleep(time.Duration(undefinedCount()) * d)
+
+	var total int64
+	total += int64(d) + undefinedOffset
+	_ = total
+
+	_ = config{Timeout: undefinedTimeout * time.Second}
+}
+
+type config struct {
+	Timeout time.Duration
+}
+
+func Product(d time.Duration) time.Duration {
+	return d * d // want `Multiplication of durations`
+}
@@ -0,0 +1,37 @@
+package unitargs
+
+import "time"
+
+func SetTimeout(timeoutMs int) {}
+
+func SetInterval(intervalSeconds int64) {}
+
+func SetDelay(delayNanos int64) {}
+
+func SetDeadline(deadline int64) {}
+
+func SetRetries(retries int) {}
+
+type Client struct{}
+
+func (c *Client) Expire(key string, ttl int) {}
+
+func cases(c *Client, d time.Duration) {
+	SetTimeout(int(d)) // want "Duration `d` is passed in nanoseconds to `SetTimeout`, which expects milliseconds: divide it by time.Millisecond"
+
+	SetTimeout(int(d / time.Millisecond))
+
+	SetTimeout(int(d / time.Second)) // want "Duration divided by `time.Second` is passed to `SetTimeout`, which expects milliseconds: divide it by time.Millisecond"
+
+	SetInterval(int64(d)) // want "Duration `d` is passed in nanoseconds to `SetInterval`, which expects seconds"
+
+	SetInterval(int64(d.Seconds()))
+
+	SetDelay(int64(d))
+
+	SetDeadline(int64(d)) // want "Duration `d` is passed in nanoseconds to `SetDeadline`, which expects seconds"
+
+	c.Expire("key", int(d)) // want "Duration `d` is passed in nanoseconds to `Expire`, which expects minutes"
+
+	SetRetries(int(d))
+}
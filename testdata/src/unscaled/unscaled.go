@@ -0,0 +1,29 @@
+package unscaled
+
+import "time"
+
+type timer struct {
+	elapsed time.Duration
+}
+
+func cases(t *timer, n int64) {
+	var total time.Duration
+
+	total += 100 // want "Unscaled constant `100` added to duration `total` is 100 nanoseconds: multiply it by a unit"
+
+	total -= 1e3 // want "Unscaled constant `1e3` added to duration `total` is 1000 nanoseconds"
+
+	total = total + 500 // want "Unscaled constant `500` added to duration `total`"
+
+	t.elapsed += -(50) // want "Unscaled constant `-\\(50\\)` added to duration `t.elapsed` is -50 nanoseconds"
+
+	total += 10
+
+	total += 100 * time.Millisecond
+
+	total += time.Duration(n)
+
+	n += 100
+
+	_ = total
+}
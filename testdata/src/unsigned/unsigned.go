@@ -0,0 +1,42 @@
+package unsigned
+
+import (
+	"math"
+	"time"
+)
+
+type message struct {
+	TTL uint64
+}
+
+func cases(u uint64, n uint, small uint32, msg message) {
+	_ = time.Duration(u) // want "Conversion of uint64 `u` to duration wraps to a negative duration above math.MaxInt64: check its bound first"
+
+	_ = time.Duration(msg.TTL) * time.Second // want "Conversion of uint64 `msg.TTL` to duration wraps"
+
+	_ = time.Duration(n) // want "Conversion of uint `n` to duration wraps"
+
+	_ = time.Duration(small)
+
+	_ = time.Duration(uint64(10))
+
+	_ = time.Duration(int64(u))
+}
+
+func checked(u uint64) time.Duration {
+	if u > math.MaxInt64 {
+		return 0
+	}
+
+	return time.Duration(u)
+}
+
+func checkedLater(u uint64) time.Duration {
+	d := time.Duration(u) // want "Conversion of uint64 `u` to duration wraps"
+
+	if u > math.MaxInt64 {
+		return 0
+	}
+
+	return d
+}
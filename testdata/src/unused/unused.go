@@ -0,0 +1,16 @@
+package unused
+
+import "time"
+
+func cases(jitter, spread time.Duration) {
+	_ = jitter * spread //durationcheck:ignore reason=squared jitter is intentional
+
+	//durationcheck:ignore
+	_ = jitter * spread
+
+	_ = jitter * 2 //durationcheck:ignore // want `Unused durationcheck:ignore directive`
+
+	//durationcheck:ignore reason=stale // want `Unused durationcheck:ignore directive`
+
+	_ = jitter + spread
+}
@@ -0,0 +1,79 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+type config struct {
+	Timeout time.Duration
+}
+
+var global time.Duration
+
+func contexts(ctx context.Context, d, base time.Duration, cfg *config) {
+	time.Sleep(d * time.Second) // want `Multiplication of durations`
+
+	<-time.After(base + d*time.Second) // want `Multiplication of durations`
+
+	ctx, cancel := context.WithTimeout(ctx, (d * time.Second)) // want `Multiplication of durations`
+	defer cancel()
+
+	cfg.Timeout = d * time.Second // want `Multiplication of durations`
+
+	global = d * time.Millisecond // want `Multiplication of durations`
+
+	_ = config{Timeout: d * time.Second} // want `Multiplication of durations`
+
+	_ = []time.Duration{d * time.Second} // want `Multiplication of durations`
+
+	timeout := d * time.Second // want `Multiplication of durations`
+	wait(timeout)
+
+	backoff := base
+	backoff *= d // want `Multiplication of durations in compound assignment`
+	wait(backoff)
+}
+
+func returned(d time.Duration) time.Duration {
+	return d * time.Second // want `Multiplication of durations`
+}
+
+func namedResult(d time.Duration) (timeout time.Duration) {
+	timeout = d * time.Second // want `Multiplication of durations`
+	return
+}
+
+func traced(d time.Duration) time.Duration {
+	scaled := d * time.Second // want `Multiplication of durations`
+	total := scaled
+	return total
+}
+
+func wait(d time.Duration) {}
+
+// statistics squares durations without using the products as durations
+func statistics(samples []time.Duration, mean time.Duration) float64 {
+	var sumSquares time.Duration
+	for _, s := range samples {
+		deviation := s - mean
+		sumSquares += deviation * deviation
+	}
+
+	variance := float64(sumSquares) / float64(len(samples))
+
+	squared := mean * mean
+	fmt.Println(float64(squared), (mean * mean).Seconds())
+
+	squares := mean * mean
+	squares *= mean
+	_ = int64(squares)
+
+	if mean*mean > time.Hour {
+		fmt.Println("spread")
+	}
+
+	return math.Sqrt(variance)
+}
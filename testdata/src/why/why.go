@@ -0,0 +1,14 @@
+package why
+
+import "time"
+
+type config struct {
+	retries int
+	backoff time.Duration
+}
+
+func cases(cfg config) {
+	_ = time.Duration(cfg.retries) * cfg.backoff
+
+	_ = cfg.backoff * time.Second // want `Multiplication of durations`
+}
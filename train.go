@@ -0,0 +1,181 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Trainer collects the findings of a run over a reviewed codebase, where they are accepted idioms, and suggests the
+// ignore entries of the configuration that accept them.
+type Trainer struct {
+	mu       sync.Mutex
+	findings []trainedFinding
+}
+
+// trainedFinding holds the candidate ignore entries of a finding
+type trainedFinding struct {
+	rule        string
+	position    string
+	functions   []string
+	identifiers []string
+	expression  string
+}
+
+// NewTrainer returns a Trainer without findings, to pass to WithTrainer.
+func NewTrainer() *Trainer {
+	return &Trainer{}
+}
+
+// record collects the functions returning durations called by the reported node and the duration variables it
+// reads, excluding those of the time package, along with its formatted expression
+func (t *Trainer) record(c *checker, rule string, node ast.Node) {
+	f := trainedFinding{rule: rule, position: c.pass.Fset.Position(node.Pos()).String()}
+
+	seen := map[string]bool{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if fn := c.calledFunc(n); fn != nil && fn.Pkg() != nil && fn.Pkg().Path() != "time" &&
+				c.classifier.IsDuration(c.pass.TypesInfo.TypeOf(n)) && !seen[funcKey(fn)] {
+				seen[funcKey(fn)] = true
+				f.functions = append(f.functions, funcKey(fn))
+			}
+		case *ast.Ident:
+			if v, ok := c.pass.TypesInfo.Uses[n].(*types.Var); ok && c.classifier.IsDuration(v.Type()) && !seen[n.Name] {
+				seen[n.Name] = true
+				f.identifiers = append(f.identifiers, n.Name)
+			}
+		}
+
+		return true
+	})
+
+	if s, err := printNode(node); err == nil {
+		f.expression = "^" + regexp.QuoteMeta(s) + "$"
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.findings = append(t.findings, f)
+}
+
+// suggestion is a suggested ignore entry and the number of findings it accepts
+type suggestion struct {
+	entry  string
+	count  int
+	source string
+}
+
+// suggest clusters the findings by the functions and the identifiers they involve. The functions, then the
+// identifiers, shared by at least minCount of the findings not accepted yet are suggested, most shared first; the other
+// findings are accepted by their exact expression.
+func (t *Trainer) suggest(minCount int) (functions, identifiers, expressions []suggestion) {
+	accepted := make([]bool, len(t.findings))
+
+	cluster := func(candidates func(f trainedFinding) []string) []suggestion {
+		var suggestions []suggestion
+		for {
+			counts := map[string]int{}
+			for i, f := range t.findings {
+				if accepted[i] {
+					continue
+				}
+				for _, c := range candidates(f) {
+					counts[c]++
+				}
+			}
+
+			best := suggestion{}
+			for entry, count := range counts {
+				if count > best.count || count == best.count && entry < best.entry {
+					best = suggestion{entry: entry, count: count}
+				}
+			}
+
+			if best.count < minCount || best.count == 0 {
+				return suggestions
+			}
+
+			for i, f := range t.findings {
+				if !accepted[i] && contains(candidates(f), best.entry) {
+					accepted[i] = true
+				}
+			}
+			suggestions = append(suggestions, best)
+		}
+	}
+
+	functions = cluster(func(f trainedFinding) []string { return f.functions })
+	identifiers = cluster(func(f trainedFinding) []string { return f.identifiers })
+
+	seen := map[string]int{}
+	for i, f := range t.findings {
+		if accepted[i] || f.expression == "" {
+			continue
+		}
+
+		if j, ok := seen[f.expression]; ok {
+			expressions[j].count++
+			continue
+		}
+		seen[f.expression] = len(expressions)
+		expressions = append(expressions, suggestion{entry: f.expression, count: 1, source: fmt.Sprintf("%s at %s", f.rule, f.position)})
+	}
+
+	return functions, identifiers, expressions
+}
+
+// WriteConfig writes the suggested ignore entries as a configuration file, each entry being commented with the
+// number of findings it accepts, for a human to review.
+func (t *Trainer) WriteConfig(w io.Writer, minCount int) error {
+	t.mu.Lock()
+	functions, identifiers, expressions := t.suggest(minCount)
+	total := len(t.findings)
+	t.mu.Unlock()
+
+	sequence := func(suggestions []suggestion) *yaml.Node {
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, s := range suggestions {
+			comment := fmt.Sprintf("%d findings", s.count)
+			if s.count == 1 {
+				comment = "1 finding"
+			}
+			if s.source != "" {
+				comment += ", " + s.source
+			}
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: s.entry, LineComment: comment})
+		}
+		return seq
+	}
+
+	ignore := &yaml.Node{Kind: yaml.MappingNode}
+	for _, section := range []struct {
+		key         string
+		suggestions []suggestion
+	}{{"functions", functions}, {"identifiers", identifiers}, {"expressions", expressions}} {
+		if len(section.suggestions) == 0 {
+			continue
+		}
+		ignore.Content = append(ignore.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: section.key}, sequence(section.suggestions))
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode, HeadComment: fmt.Sprintf("suggested by durationcheck -train from %d findings: review the entries before adding them to the configuration", total)}
+	if len(ignore.Content) > 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.ScalarNode, Value: "ignore"}, ignore}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
@@ -0,0 +1,47 @@
+package durationcheck
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTrain(t *testing.T) {
+	trainer := NewTrainer()
+
+	// the findings are recorded instead of being reported
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, NewAnalyzer(WithTrainer(trainer)), "train")
+
+	var buf bytes.Buffer
+	if err := trainer.WriteConfig(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# suggested by durationcheck -train from 6 findings: review the entries before adding them to the configuration
+ignore:
+  functions:
+    - train.Clock.Skew # 1 finding
+    - train.jitter # 1 finding
+  identifiers:
+    - retry # 2 findings
+    - backoff # 1 finding
+    - d # 1 finding
+`
+
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// entries shared by fewer findings are replaced by the expressions of the findings
+	buf.Reset()
+	if err := trainer.WriteConfig(&buf, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); !strings.Contains(got, "  identifiers:\n    - d # 3 findings\n    - retry # 2 findings\n  expressions:\n    - ^backoff \\* time\\.Second$ # 1 finding, mul at ") {
+		t.Errorf("unexpected configuration:\n%s", got)
+	}
+}
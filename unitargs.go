@@ -0,0 +1,141 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// unitWords map the last name component of integer parameters to the unit they are expected in
+var unitWords = map[string]string{
+	"us":           "Microsecond",
+	"usec":         "Microsecond",
+	"micros":       "Microsecond",
+	"microseconds": "Microsecond",
+	"ms":           "Millisecond",
+	"msec":         "Millisecond",
+	"millis":       "Millisecond",
+	"milliseconds": "Millisecond",
+	"sec":          "Second",
+	"secs":         "Second",
+	"seconds":      "Second",
+	"mins":         "Minute",
+	"minutes":      "Minute",
+	"hours":        "Hour",
+}
+
+// unitAbbreviations are the units accepted by -unit-apis
+var unitAbbreviations = map[string]string{
+	"ns": "Nanosecond",
+	"us": "Microsecond",
+	"ms": "Millisecond",
+	"s":  "Second",
+	"m":  "Minute",
+	"h":  "Hour",
+}
+
+// parseUnitAPIs parses the -unit-apis entries, e.g. `example.com/thirdparty.SetTimeout=ms` or
+// `example.com/thirdparty.Client.SetDeadline=s`, into the unit of the integer parameters of each function
+func parseUnitAPIs(entries []string) (map[string]string, error) {
+	apis := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		fn, abbrev, ok := strings.Cut(entry, "=")
+		unit, known := unitAbbreviations[abbrev]
+		if !ok || !known || fn == "" {
+			return nil, fmt.Errorf("invalid -unit-apis entry %q, expected function=unit with unit one of ns, us, ms, s, m, h", entry)
+		}
+		apis[fn] = unit
+	}
+
+	return apis, nil
+}
+
+// checkUnitArguments reports durations converted to integers and passed to integer parameters expecting another
+// unit, e.g. `thirdparty.SetTimeoutMs(int(d))` which passes nanoseconds. The unit of a parameter comes from
+// -unit-apis or from its name, e.g. `timeoutMs`.
+func (c *checker) checkUnitArguments(call *ast.CallExpr) {
+	fn := c.calledFunc(call)
+	if fn == nil {
+		return
+	}
+
+	sig := fn.Type().(*types.Signature)
+	configured := c.unitAPIs[funcKey(fn)]
+
+	for i, arg := range call.Args {
+		if i >= sig.Params().Len() || sig.Variadic() && i == sig.Params().Len()-1 {
+			break
+		}
+
+		param := sig.Params().At(i)
+		if !isInteger(param.Type()) || c.classifier.IsDuration(param.Type()) || !c.isDurationToIntConversion(arg) {
+			continue
+		}
+
+		unit := configured
+		if unit == "" {
+			if words := splitWords(param.Name()); len(words) > 0 {
+				unit = unitWords[words[len(words)-1]]
+			}
+		}
+
+		if unit == "" || unit == "Nanosecond" {
+			continue
+		}
+
+		qualifier, ok := c.timeQualifier()
+		if !ok {
+			qualifier = "time"
+		}
+
+		expects := strings.ToLower(unit) + "s"
+		d := ast.Unparen(ast.Unparen(arg).(*ast.CallExpr).Args[0])
+
+		quo, ok := d.(*ast.BinaryExpr)
+		if !ok || quo.Op != token.QUO || !isUnitConstant(c.pass, quo.Y) {
+			c.reportf(RuleUnitArgs, arg, "Duration `%s` is passed in nanoseconds to `%s`, which expects %s: divide it by %s.%s",
+				c.formatExpr(d), fn.Name(), expects, qualifier, unit)
+			continue
+		}
+
+		if divisor, _ := constant.Int64Val(c.pass.TypesInfo.Types[quo.Y].Value); divisor != unitValue(unit) {
+			c.reportf(RuleUnitArgs, arg, "Duration divided by `%s` is passed to `%s`, which expects %s: divide it by %s.%s",
+				c.formatExpr(quo.Y), fn.Name(), expects, qualifier, unit)
+		}
+	}
+}
+
+// funcKey identifies a function in -unit-apis: its package path followed by its name, or by its receiver type name
+// and its name for methods
+func funcKey(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if recv := sig.Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return fn.Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+		}
+	}
+
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// unitValue returns the value of the time package unit constant with the given name
+func unitValue(name string) int64 {
+	for _, u := range units {
+		if u.name == name {
+			return u.value
+		}
+	}
+
+	return 0
+}
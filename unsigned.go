@@ -0,0 +1,90 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkUnsignedConversion reports conversions of 64-bit unsigned integers to durations, e.g. `time.Duration(u)`
+// where u is read from a wire format, which wrap to negative durations above math.MaxInt64, unless the value is
+// compared to a bound earlier in the function
+func (c *checker) checkUnsignedConversion(cur inspector.Cursor, call *ast.CallExpr) {
+	if !c.classifier.IsConversion(call) {
+		return
+	}
+
+	arg := call.Args[0]
+	tv, ok := c.pass.TypesInfo.Types[arg]
+	if !ok || tv.Value != nil || !c.isUnsigned64(tv.Type) {
+		return
+	}
+
+	if c.isBoundChecked(cur, arg) {
+		return
+	}
+
+	c.reportf(RuleUnsigned, call, "Conversion of %s `%s` to duration wraps to a negative duration above math.MaxInt64: check its bound first",
+		tv.Type, c.formatExpr(arg))
+}
+
+// isUnsigned64 reports whether the type is an unsigned integer of 64 bits
+func (c *checker) isUnsigned64(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsUnsigned == 0 {
+		return false
+	}
+
+	sizes := c.pass.TypesSizes
+	if sizes == nil {
+		sizes = types.SizesFor("gc", "amd64")
+	}
+
+	return sizes.Sizeof(basic) == 8
+}
+
+// isBoundChecked reports whether the expression is compared to a value before the cursor in the enclosing function
+func (c *checker) isBoundChecked(cur inspector.Cursor, expr ast.Expr) bool {
+	body := enclosingBody(cur)
+	if body == nil {
+		return false
+	}
+
+	value := formatNode(ast.Unparen(expr))
+	checked := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if checked || n == nil || n.Pos() >= cur.Node().Pos() {
+			return false
+		}
+
+		cmp, ok := n.(*ast.BinaryExpr)
+		if !ok {
+			return true
+		}
+
+		switch cmp.Op {
+		case token.LSS, token.LEQ, token.GTR, token.GEQ:
+			checked = formatNode(ast.Unparen(cmp.X)) == value || formatNode(ast.Unparen(cmp.Y)) == value
+		}
+
+		return !checked
+	})
+
+	return checked
+}
+
+// enclosingBody returns the body of the function enclosing the cursor
+func enclosingBody(cur inspector.Cursor) *ast.BlockStmt {
+	for fn := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		switch f := fn.Node().(type) {
+		case *ast.FuncDecl:
+			return f.Body
+		case *ast.FuncLit:
+			return f.Body
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package durationcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// usedAsDuration returns true if the value of the expression flows into a context consuming a duration, for
+// -usage-context: an argument of a duration parameter, e.g. of time.Sleep or context.WithTimeout, a duration result,
+// a duration field, element or package variable, or a local variable whose value does in turn. Products converted to
+// numbers, compared or formatted, e.g. the squared deviations of statistics, aren't used as durations.
+func (c *checker) usedAsDuration(cur inspector.Cursor) bool {
+	return c.flowsToDuration(cur, map[types.Object]bool{})
+}
+
+// flowsToDuration is usedAsDuration, visited holding the local variables already traced
+func (c *checker) flowsToDuration(cur inspector.Cursor, visited map[types.Object]bool) bool {
+	info := c.pass.TypesInfo
+	for {
+		expr, ok := cur.Node().(ast.Expr)
+		if !ok {
+			return false
+		}
+
+		parent := cur.Parent()
+		switch p := parent.Node().(type) {
+		case *ast.ParenExpr:
+			cur = parent
+			continue
+		case *ast.UnaryExpr:
+			switch p.Op {
+			case token.ADD, token.SUB:
+				cur = parent
+				continue
+			case token.AND:
+				// the value escapes, assume it's used
+				return true
+			}
+			return false
+		case *ast.BinaryExpr:
+			// further duration arithmetic, e.g. `base + d * jitter`, unlike comparisons
+			if !c.classifier.IsDuration(info.TypeOf(p)) {
+				return false
+			}
+			cur = parent
+			continue
+		case *ast.CallExpr:
+			if expr == p.Fun {
+				return false
+			}
+
+			if tv, ok := info.Types[p.Fun]; ok && tv.IsType() {
+				if !c.classifier.IsDuration(tv.Type) {
+					return false
+				}
+				cur = parent
+				continue
+			}
+
+			return c.classifier.IsDuration(argType(info, p, expr))
+		case *ast.ReturnStmt:
+			_, sig := c.enclosingFunc(cur)
+			i := indexOf(p.Results, expr)
+			return sig != nil && i >= 0 && i < sig.Results().Len() && len(p.Results) == sig.Results().Len() &&
+				c.classifier.IsDuration(sig.Results().At(i).Type())
+		case *ast.AssignStmt:
+			i := indexOf(p.Rhs, expr)
+			if i < 0 || len(p.Lhs) != len(p.Rhs) {
+				return false
+			}
+			return c.storedAsDuration(cur, p.Lhs[i], visited)
+		case *ast.ValueSpec:
+			i := indexOf(p.Values, expr)
+			if i < 0 || len(p.Names) != len(p.Values) {
+				return false
+			}
+			return c.storedAsDuration(cur, p.Names[i], visited)
+		case *ast.KeyValueExpr:
+			if expr != p.Value {
+				return false
+			}
+
+			lit, ok := parent.Parent().Node().(*ast.CompositeLit)
+			if !ok {
+				return false
+			}
+
+			if key, ok := p.Key.(*ast.Ident); ok {
+				if field, ok := info.Uses[key].(*types.Var); ok && field.IsField() {
+					return c.classifier.IsDuration(field.Type())
+				}
+			}
+			return c.classifier.IsDuration(elemType(info.TypeOf(lit)))
+		case *ast.CompositeLit:
+			t := info.TypeOf(p)
+			if t == nil {
+				return false
+			}
+
+			if s, ok := t.Underlying().(*types.Struct); ok {
+				i := indexOf(p.Elts, expr)
+				return i >= 0 && i < s.NumFields() && c.classifier.IsDuration(s.Field(i).Type())
+			}
+			return c.classifier.IsDuration(elemType(t))
+		case *ast.SendStmt:
+			return expr == p.Value && c.classifier.IsDuration(elemType(info.TypeOf(p.Chan)))
+		default:
+			return false
+		}
+	}
+}
+
+// storedAsDuration returns true if a value stored in the target is used as a duration: the target is a local
+// variable whose uses are, or another duration variable, e.g. a field, a package variable or a named result
+func (c *checker) storedAsDuration(cur inspector.Cursor, target ast.Expr, visited map[types.Object]bool) bool {
+	info := c.pass.TypesInfo
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok {
+		return c.classifier.IsDuration(info.TypeOf(target))
+	}
+
+	if ident.Name == "_" {
+		return false
+	}
+
+	v, ok := info.ObjectOf(ident).(*types.Var)
+	if !ok {
+		return false
+	}
+
+	if v.Pkg() == nil || v.Parent() == v.Pkg().Scope() || c.isResult(cur, v) {
+		return c.classifier.IsDuration(v.Type())
+	}
+
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	// the uses of the variable, closures included, are in its function declaration
+	var body inspector.Cursor
+	found := false
+	for fn := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		body, found = fn, true
+	}
+	if !found {
+		return false
+	}
+
+	for use := range body.Preorder((*ast.Ident)(nil)) {
+		if info.Uses[use.Node().(*ast.Ident)] != v || isAssigned(use) {
+			continue
+		}
+
+		if c.flowsToDuration(use, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isResult returns true if the variable is a named result of the function enclosing the cursor, returned as is by a
+// bare return
+func (c *checker) isResult(cur inspector.Cursor, v *types.Var) bool {
+	_, sig := c.enclosingFunc(cur)
+	if sig == nil {
+		return false
+	}
+
+	for i := range sig.Results().Len() {
+		if sig.Results().At(i) == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAssigned returns true if the identifier is assigned rather than read, e.g. x in `x = d` or `x += d`
+func isAssigned(cur inspector.Cursor) bool {
+	stmt, ok := cur.Parent().Node().(*ast.AssignStmt)
+	return ok && indexOf(stmt.Lhs, cur.Node().(ast.Expr)) >= 0
+}
+
+// argType returns the type of the parameter the argument of the call is passed to, nil if unknown
+func argType(info *types.Info, call *ast.CallExpr, arg ast.Expr) types.Type {
+	t := info.TypeOf(call.Fun)
+	if t == nil {
+		return nil
+	}
+
+	sig, ok := t.Underlying().(*types.Signature)
+	i := indexOf(call.Args, arg)
+	if !ok || i < 0 {
+		return nil
+	}
+
+	params := sig.Params()
+	switch {
+	case sig.Variadic() && i >= params.Len()-1 && !call.Ellipsis.IsValid():
+		return elemType(params.At(params.Len() - 1).Type())
+	case i < params.Len():
+		return params.At(i).Type()
+	default:
+		return nil
+	}
+}
+
+// elemType returns the type of the elements of a slice, array, map, pointer to array or channel type, nil otherwise
+func elemType(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Array:
+		return u.Elem()
+	case *types.Map:
+		return u.Elem()
+	case *types.Chan:
+		return u.Elem()
+	case *types.Pointer:
+		if a, ok := u.Elem().Underlying().(*types.Array); ok {
+			return a.Elem()
+		}
+	}
+
+	return nil
+}
+
+// indexOf returns the index of the expression in the list, -1 if absent
+func indexOf(list []ast.Expr, expr ast.Expr) int {
+	for i, e := range list {
+		if e == expr {
+			return i
+		}
+	}
+
+	return -1
+}
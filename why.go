@@ -0,0 +1,108 @@
+package durationcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charithe/durationcheck/durationexpr"
+)
+
+// formats of the -why traces
+const (
+	whyOff  = ""
+	whyText = "text"
+	whyJSON = "json"
+	whyDOT  = "dot"
+)
+
+var (
+	// whyOutput receives the -why traces
+	whyOutput io.Writer = os.Stderr
+	// whyMu serializes the traces of packages analyzed concurrently
+	whyMu sync.Mutex
+)
+
+// whyTrace is the JSON encoding of a durationexpr.Trace
+type whyTrace struct {
+	Expr     string      `json:"expr"`
+	Kind     string      `json:"kind"`
+	Reason   string      `json:"reason"`
+	Operands []*whyTrace `json:"operands,omitempty"`
+}
+
+// explain writes the trace of the classification of the operands of a reported expression in the -why format
+func (c *checker) explain(expr ast.Expr, reason string, operands ...ast.Expr) {
+	if c.settings.whyFormat == whyOff {
+		return
+	}
+
+	trace := &durationexpr.Trace{Expr: expr, Kind: durationexpr.Unit, Reason: reason}
+	for _, op := range operands {
+		trace.Operands = append(trace.Operands, c.classifier.Explain(op))
+	}
+
+	pos := c.pass.Fset.Position(expr.Pos()).String()
+
+	var buf strings.Builder
+	switch c.settings.whyFormat {
+	case whyText:
+		fmt.Fprintf(&buf, "%s: why:\n", pos)
+		writeTextTrace(&buf, trace, 1)
+	case whyJSON:
+		data, err := json.Marshal(struct {
+			Pos   string    `json:"pos"`
+			Trace *whyTrace `json:"trace"`
+		}{pos, encodeTrace(trace)})
+		if err != nil {
+			c.fail(fmt.Errorf("encoding -why trace: %w", err))
+			return
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	case whyDOT:
+		fmt.Fprintf(&buf, "digraph %q {\n", pos)
+		writeDOTTrace(&buf, trace, new(int))
+		buf.WriteString("}\n")
+	}
+
+	whyMu.Lock()
+	defer whyMu.Unlock()
+
+	if _, err := io.WriteString(whyOutput, buf.String()); err != nil {
+		c.fail(fmt.Errorf("writing -why trace: %w", err))
+	}
+}
+
+func writeTextTrace(w io.Writer, trace *durationexpr.Trace, depth int) {
+	fmt.Fprintf(w, "%s%s: %s, %s\n", strings.Repeat("  ", depth), formatNode(trace.Expr), trace.Kind, trace.Reason)
+	for _, op := range trace.Operands {
+		writeTextTrace(w, op, depth+1)
+	}
+}
+
+// writeDOTTrace writes the nodes of the trace and the edges to its operands, it returns the ID of its node
+func writeDOTTrace(w io.Writer, trace *durationexpr.Trace, next *int) int {
+	id := *next
+	*next++
+
+	fmt.Fprintf(w, "  n%d [label=%q];\n", id, fmt.Sprintf("%s\n%s: %s", formatNode(trace.Expr), trace.Kind, trace.Reason))
+	for _, op := range trace.Operands {
+		fmt.Fprintf(w, "  n%d -> n%d;\n", id, writeDOTTrace(w, op, next))
+	}
+
+	return id
+}
+
+func encodeTrace(trace *durationexpr.Trace) *whyTrace {
+	t := &whyTrace{Expr: formatNode(trace.Expr), Kind: trace.Kind.String(), Reason: trace.Reason}
+	for _, op := range trace.Operands {
+		t.Operands = append(t.Operands, encodeTrace(op))
+	}
+
+	return t
+}
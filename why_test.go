@@ -0,0 +1,90 @@
+package durationcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestWhy(t *testing.T) {
+	var buf bytes.Buffer
+	whyOutput = &buf
+	t.Cleanup(func() { whyOutput = os.Stderr })
+
+	testCases := map[string]func(t *testing.T, out string){
+		whyText: func(t *testing.T, out string) {
+			want := "why.go:13:6: why:\n" +
+				"  cfg.backoff * time.Second: unit, multiplication of operands carrying a unit\n" +
+				"    cfg.backoff: unit, operand carrying a unit\n" +
+				"      cfg: count, variable of type why.config\n" +
+				"      backoff: unit, variable of type time.Duration\n" +
+				"    time.Second: unit, constant of type time.Duration\n"
+			if !strings.HasSuffix(out, want) {
+				t.Errorf("unexpected trace:\n%s", out)
+			}
+		},
+		whyJSON: func(t *testing.T, out string) {
+			var trace struct {
+				Pos   string
+				Trace whyTrace
+			}
+			if err := json.Unmarshal([]byte(out), &trace); err != nil {
+				t.Fatal(err)
+			}
+			if len(trace.Trace.Operands) != 2 || trace.Trace.Operands[1].Reason != "constant of type time.Duration" {
+				t.Errorf("unexpected trace: %+v", trace)
+			}
+		},
+		whyDOT: func(t *testing.T, out string) {
+			if !strings.HasPrefix(out, "digraph ") || !strings.Contains(out, "n0 -> n1;") {
+				t.Errorf("unexpected graph:\n%s", out)
+			}
+		},
+	}
+
+	for format, check := range testCases {
+		t.Run(format, func(t *testing.T) {
+			buf.Reset()
+			s := newSettings()
+			s.whyFormat = format
+
+			analysistest.Run(t, analysistest.TestData(), newAnalyzer(s), "why")
+			check(t, buf.String())
+		})
+	}
+}
+
+func TestWhyWriteError(t *testing.T) {
+	whyOutput = failingWriter{}
+	t.Cleanup(func() { whyOutput = os.Stderr })
+
+	s := newSettings()
+	s.whyFormat = whyText
+
+	var errs errorRecorder
+	results := analysistest.Run(&errs, analysistest.TestData(), newAnalyzer(s), "why")
+
+	if len(results) != 1 || results[0].Err == nil || results[0].Err.Error() != "writing -why trace: disk full" {
+		t.Errorf("got results %v, want the write error", results)
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// errorRecorder implements analysistest.Testing, recording the errors instead of failing the test
+type errorRecorder []string
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	*r = append(*r, fmt.Sprintf(format, args...))
+}
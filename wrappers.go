@@ -0,0 +1,82 @@
+package durationcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+)
+
+// wrapperType is a struct wrapping a time.Duration field, e.g. the metav1.Duration of Kubernetes APIs
+type wrapperType struct {
+	path  string
+	name  string
+	field string
+}
+
+// profiles are the sets of third-party wrapper types that can be enabled with -profiles
+var profiles = map[string][]wrapperType{
+	"kubernetes": {{path: "k8s.io/apimachinery/pkg/apis/meta/v1", name: "Duration", field: "Duration"}},
+}
+
+// parseProfiles returns the wrapper types of the profiles
+func parseProfiles(names []string) ([]wrapperType, error) {
+	var wrappers []wrapperType
+	for _, name := range names {
+		profile, ok := profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown profile %q", name)
+		}
+		wrappers = append(wrappers, profile...)
+	}
+
+	return wrappers, nil
+}
+
+// wrapper returns the wrapper type of the enabled profiles matching the type, or nil
+func (c *checker) wrapper(t types.Type) *wrapperType {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	for i, w := range c.wrappers {
+		if named.Obj().Pkg().Path() == w.path && named.Obj().Name() == w.name {
+			return &c.wrappers[i]
+		}
+	}
+
+	return nil
+}
+
+// checkWrapperLiteral reports wrapper types of the enabled profiles initialized with bare numbers above the
+// -unscaled-threshold, e.g. `metav1.Duration{Duration: 30}` which is 30 nanoseconds. Their duration fields get the
+// other checks like any time.Duration.
+func (c *checker) checkWrapperLiteral(lit *ast.CompositeLit) {
+	w := c.wrapper(c.pass.TypesInfo.TypeOf(lit))
+	if w == nil {
+		return
+	}
+
+	for _, elt := range lit.Elts {
+		value := elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != w.field {
+				continue
+			}
+			value = kv.Value
+		}
+
+		if !isNumberLiteral(value) {
+			continue
+		}
+
+		n, exact := constant.Int64Val(constant.ToInt(c.pass.TypesInfo.Types[value].Value))
+		if !exact || n <= int64(c.settings.unscaledThreshold) && n >= -int64(c.settings.unscaledThreshold) {
+			continue
+		}
+
+		c.reportf(RuleWrapperInit, value, "%s.%s initialized with bare number `%s` is %d nanoseconds: multiply it by a unit",
+			c.formatExpr(ast.Unparen(lit.Type)), w.field, c.formatExpr(value), n)
+	}
+}